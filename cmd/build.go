@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/nyambati/fuse/internal/validate"
+)
+
+func newBuildCmd() *cobra.Command {
+	var (
+		opts    pipelineOptions
+		output  string
+		stdout  bool
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "build",
+		Short: "Render the Alertmanager config from the Fuse project",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			res, err := runPipeline(cmd, opts)
+			if err != nil {
+				return err
+			}
+
+			// The config refers to its templates by path, so on its own it
+			// does not load in Alertmanager.
+			if stdout && len(res.files) > 0 {
+				res.diags = validate.Merge(res.diags, []diag.Diagnostic{diag.Warn(
+					"BUILD_TEMPLATES_NOT_WRITTEN",
+					fmt.Sprintf("--stdout does not write the %d template file(s) the config references; build without --stdout to write them to %s/ next to the config", len(res.files), parse.TemplateDir),
+					"",
+				)})
+				res.exit = validate.ExitCode(res.diags, opts.strict)
+			}
+
+			// Diagnostics go to stderr so --stdout stays pipeable.
+			if err := printDiagnostics(os.Stderr, res.root, res.diags, jsonOut); err != nil {
+				return err
+			}
+
+			if err := exitError(res.exit); err != nil {
+				return fmt.Errorf("%w; refusing to write output", err)
+			}
+
+			data, err := am.Marshal(res.amc)
+			if err != nil {
+				return err
+			}
+
+			if stdout {
+				_, err := os.Stdout.Write(data)
+				return err
+			}
+
+			target := res.config.OutputPath(res.root)
+			if output != "" {
+				target, err = filepath.Abs(output)
+				if err != nil {
+					return fmt.Errorf("failed to resolve output path: %w", err)
+				}
+			}
+
+			dir := filepath.Dir(target)
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("failed to create output directory: %w", err)
+			}
+			// Templates go in first, so the config never references a
+			// missing file. The templates directory may hold files fuse did
+			// not write; only those of earlier builds are removed.
+			if len(res.files) > 0 {
+				if err := am.WriteManaged(dir, parse.TemplateDir, res.files); err != nil {
+					return err
+				}
+			}
+			if err := os.WriteFile(target, data, 0o644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", target, err)
+			}
+			if len(res.files) > 0 {
+				if err := am.PruneManaged(dir, parse.TemplateDir, res.files); err != nil {
+					return err
+				}
+			}
+
+			fmt.Fprintf(os.Stderr, "Wrote %s\n", target)
+			if len(res.files) > 0 {
+				fmt.Fprintf(os.Stderr, "Wrote %d template file(s) to %s\n", len(res.files), filepath.Join(dir, parse.TemplateDir))
+			}
+			return nil
+		},
+	}
+
+	opts.bindFlags(cmd)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to this path instead of build.output from .fuse.yaml")
+	cmd.Flags().BoolVar(&stdout, "stdout", false, "Write the rendered config to stdout instead of a file")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output diagnostics as JSON")
+
+	return cmd
+}
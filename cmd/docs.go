@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/nyambati/fuse/internal/channels"
+	"github.com/spf13/cobra"
+)
+
+func newDocsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "Generate reference documentation",
+	}
+	cmd.AddCommand(newDocsChannelsCmd())
+	return cmd
+}
+
+func newDocsChannelsCmd() *cobra.Command {
+	var schema bool
+
+	cmd := &cobra.Command{
+		Use:   "channels",
+		Short: "Print the channel type reference, or a JSON Schema for channels.yaml",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if schema {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(channels.Schema())
+			}
+			return channels.WriteMarkdown(os.Stdout)
+		},
+	}
+
+	cmd.Flags().BoolVar(&schema, "schema", false, "Print a JSON Schema for channels.yaml instead of markdown")
+
+	return cmd
+}
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/config"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/nyambati/fuse/internal/utils"
+	"github.com/nyambati/fuse/internal/validate"
+)
+
+// pipelineOptions holds the flags shared by commands that load, translate
+// and validate a Fuse project.
+type pipelineOptions struct {
+	path          string
+	teams         []string
+	secretsProv   string
+	secretsConfig string
+	amtoolPath    string
+	strict        bool
+	verbose       bool
+}
+
+func (o *pipelineOptions) bindFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.path, "path", ".", "Project path or subdirectory")
+	cmd.Flags().StringSliceVar(&o.teams, "team", nil, "Validate only specific team(s)")
+	cmd.Flags().StringVar(&o.secretsProv, "secrets", "env", "Secrets provider: env|sops|vault|ssm")
+	cmd.Flags().StringVar(&o.secretsConfig, "secrets-config", "", "Secrets provider config file")
+	cmd.Flags().StringVar(&o.amtoolPath, "amtool", "", "Path to amtool for check-config (optional)")
+	cmd.Flags().BoolVar(&o.strict, "strict", false, "Treat warnings as errors")
+	cmd.Flags().BoolVarP(&o.verbose, "verbose", "v", false, "Report additional detail, such as which provider resolved each secret")
+}
+
+// pipelineResult is the outcome of loading, translating and validating a project.
+type pipelineResult struct {
+	root   string
+	config config.Config
+	proj   dsl.Project
+	amc    am.Config
+	files  map[string][]byte // written next to the config, e.g. templates
+	diags  []diag.Diagnostic
+	exit   int
+}
+
+// runPipeline discovers the project, loads the DSL, translates it into an
+// Alertmanager config and validates both. Returned errors are fatal setup
+// failures; everything else is reported through diagnostics.
+func runPipeline(cmd *cobra.Command, o pipelineOptions) (pipelineResult, error) {
+	var res pipelineResult
+
+	// 1) Discover project root
+	root, err := utils.FindProjectRoot(o.path)
+	if err != nil {
+		return res, fmt.Errorf("not a Fuse project (no .fuse.yaml): %w", err)
+	}
+	res.root = root
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		return res, err
+	}
+	res.config = cfg
+
+	// 2) Load DSL (global + teams)
+	proj, loadDiags := dsl.LoadProject(root, o.teams)
+	res.proj = proj
+
+	// 3) Secrets provider (.fuse.yaml decides unless --secrets is given)
+	prov, err := newSecretsProvider(cmd, o, root, cfg)
+	if err != nil {
+		return res, fmt.Errorf("secrets provider: %w", err)
+	}
+
+	// 4) Build AM model in-memory (translate DSL → AM)
+	amc, parseDiags := parse.ToAlertmanager(proj, prov, parse.Options{
+		Strict:          o.strict,
+		ReceiverPattern: cfg.Receivers.NamePattern,
+	})
+	res.amc = amc
+	res.files = parse.TemplateFiles(proj)
+
+	// 5) Semantic validation
+	valDiags := validate.Project(proj, amc, validate.Options{
+		Strict:          o.strict,
+		SecretAllowlist: cfg.SecretScan.Allowlist,
+		ReceiverPattern: cfg.Receivers.NamePattern,
+	})
+
+	// 6) (Optional) amtool check-config
+	toolDiags := am.CheckWithAmtool(amc, res.files, o.amtoolPath) // returns empty if not configured/found
+
+	var infoDiags []diag.Diagnostic
+	if o.verbose || (!cmd.Flags().Changed("verbose") && cfg.Defaults.Verbose) {
+		infoDiags = secretResolutionDiags(prov)
+	}
+
+	// 7) Collate diagnostics and decide exit code
+	res.diags = validate.Merge(loadDiags, parseDiags, valDiags, toolDiags, infoDiags)
+	res.exit = validate.ExitCode(res.diags, o.strict)
+
+	return res, nil
+}
+
+// printDiagnostics writes diagnostics either as JSON or one per line. Files
+// are shown relative to the project root; in the line format a diagnostic
+// with a line number is followed by the offending source line and a caret.
+func printDiagnostics(w io.Writer, root string, all []diag.Diagnostic, jsonOut bool) error {
+	rel := make([]diag.Diagnostic, len(all))
+	for i, d := range all {
+		d.File = relPath(root, d.File)
+		rel[i] = d
+	}
+
+	if jsonOut {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rel); err != nil {
+			return fmt.Errorf("json output: %w", err)
+		}
+		return nil
+	}
+
+	sources := map[string][]string{}
+	for i, d := range rel {
+		if d.File == "" {
+			fmt.Fprintf(w, "%s: %s\n", d.Level, d.Message)
+			continue
+		}
+
+		loc := d.File
+		if d.Line > 0 {
+			loc += fmt.Sprintf(":%d", d.Line)
+			if d.Column > 0 {
+				loc += fmt.Sprintf(":%d", d.Column)
+			}
+		}
+		fmt.Fprintf(w, "%s: %s: %s\n", loc, d.Level, d.Message)
+
+		if d.Line > 0 {
+			file := all[i].File
+			lines, ok := sources[file]
+			if !ok {
+				lines = readLines(file)
+				sources[file] = lines
+			}
+			printSnippet(w, lines, d.Line, d.Column)
+		}
+	}
+	return nil
+}
+
+// relPath returns file relative to root when it lies inside the project.
+func relPath(root, file string) string {
+	if root == "" || !filepath.IsAbs(file) {
+		return file
+	}
+	r, err := filepath.Rel(root, file)
+	if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+		return file
+	}
+	return r
+}
+
+// readLines returns the lines of file, or nil when it cannot be read.
+func readLines(file string) []string {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(strings.TrimRight(string(b), "\n"), "\n")
+}
+
+// printSnippet prints source line n with a caret under column col (1-based).
+func printSnippet(w io.Writer, lines []string, n, col int) {
+	if n > len(lines) {
+		return
+	}
+	line := strings.TrimRight(lines[n-1], "\r")
+	gutter := fmt.Sprintf("%5d | ", n)
+	fmt.Fprintf(w, "%s%s\n", gutter, line)
+	if col < 1 {
+		return
+	}
+
+	// Keep tabs so the caret lines up with the source.
+	var pad strings.Builder
+	for i, r := range []rune(line) {
+		if i >= col-1 {
+			break
+		}
+		if r == '\t' {
+			pad.WriteRune('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+	fmt.Fprintf(w, "%s| %s^\n", strings.Repeat(" ", len(gutter)-2), pad.String())
+}
+
+// exitError maps a validate.ExitCode result onto the command error.
+func exitError(exit int) error {
+	switch exit {
+	case 0:
+		return nil
+	case 1:
+		// diff “changes” isn’t relevant here; treat as warnings
+		return nil
+	case 2:
+		// warnings - non-strict
+		return nil
+	case 3:
+		return fmt.Errorf("validation errors")
+	case 4:
+		return fmt.Errorf("external tool/provider failure")
+	default:
+		return fmt.Errorf("unknown exit code %d", exit)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -15,6 +16,11 @@ func NewRootCmd() *cobra.Command {
 	// Add subcommands
 	root.AddCommand(newInitCmd())
 	root.AddCommand(newValidateCmd())
+	root.AddCommand(newBuildCmd())
+	root.AddCommand(newDocsCmd())
+	root.AddCommand(newTemplateCmd())
+	root.AddCommand(newTestCmd())
+	root.AddCommand(newRouteCmd())
 	root.SilenceUsage = true
 	root.SilenceErrors = true
 
@@ -23,6 +29,7 @@ func NewRootCmd() *cobra.Command {
 
 func Execute() {
 	if err := NewRootCmd().Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/routetest"
+)
+
+func newRouteCmd() *cobra.Command {
+	var (
+		opts pipelineOptions
+		at   string
+		tree bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "route [label=value ...]",
+		Short: "Show where an alert with the given labels is routed",
+		Long: `Route builds the Alertmanager config and dispatches an alert with the given
+labels through its route tree, like "amtool config routes test". For every
+route the alert reaches it prints the path from the root, naming the team
+and flow each route was built from, the receiver, the grouping and timing
+in effect and whether its silence windows mute it at --at.
+
+With --tree it prints the whole route tree instead, marking the routes the
+labels reach when labels are given.`,
+		Example: `  fuse route severity=critical team=payments env=prod
+  fuse route team=payments --at 2024-06-01T10:00:00Z
+  fuse route --tree`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			labels, err := parseLabelArgs(args)
+			if err != nil {
+				return err
+			}
+			if len(labels) == 0 && !tree {
+				return fmt.Errorf("no labels given; pass label=value pairs or --tree")
+			}
+
+			now := time.Now()
+			if at != "" {
+				now, err = time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("invalid --at %q: expected RFC 3339, e.g. 2024-06-01T10:00:00Z", at)
+				}
+			}
+
+			res, err := runPipeline(cmd, opts)
+			if err != nil {
+				return err
+			}
+			if err := printDiagnostics(os.Stderr, res.root, res.diags, false); err != nil {
+				return err
+			}
+			if err := exitError(res.exit); err != nil {
+				return fmt.Errorf("%w; cannot route", err)
+			}
+
+			var matches []am.RouteMatch
+			if len(labels) > 0 {
+				matches, err = res.amc.Route.Match(labels)
+				if err != nil {
+					return err
+				}
+			}
+
+			w := cmd.OutOrStdout()
+			if tree {
+				printRouteTree(w, &res.amc.Route, matches)
+				return nil
+			}
+			return printRouteMatches(w, res.amc, labels, matches, now)
+		},
+	}
+
+	opts.bindFlags(cmd)
+	cmd.Flags().StringVar(&at, "at", "", "Evaluate silence windows at this RFC 3339 time (default: now)")
+	cmd.Flags().BoolVar(&tree, "tree", false, "Print the whole route tree")
+
+	return cmd
+}
+
+// parseLabelArgs parses label=value arguments; values may be quoted.
+func parseLabelArgs(args []string) (map[string]string, error) {
+	labels := map[string]string{}
+	for _, a := range args {
+		name, value, ok := strings.Cut(a, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid label %q: expected label=value", a)
+		}
+		labels[name] = strings.Trim(strings.TrimSpace(value), `"'`)
+	}
+	return labels, nil
+}
+
+func printRouteMatches(w io.Writer, cfg am.Config, labels map[string]string, matches []am.RouteMatch, at time.Time) error {
+	fmt.Fprintf(w, "Labels: %s\n", routetest.FormatLabels(labels))
+
+	var receivers []string
+	for i, m := range matches {
+		fmt.Fprintf(w, "\nRoute %d of %d:\n", i+1, len(matches))
+		for depth, r := range m.Chain {
+			fmt.Fprintf(w, "  %s%s\n", strings.Repeat("  ", depth), describeRoute(r, depth == 0))
+		}
+
+		o := m.Options()
+		fmt.Fprintf(w, "  receiver:        %s\n", m.Receiver)
+		fmt.Fprintf(w, "  group_by:        %s\n", formatGroupBy(o.GroupBy))
+		fmt.Fprintf(w, "  group_wait:      %s\n", o.GroupWait)
+		fmt.Fprintf(w, "  group_interval:  %s\n", o.GroupInterval)
+		fmt.Fprintf(w, "  repeat_interval: %s\n", o.RepeatInterval)
+
+		if err := printIntervals(w, cfg, "silence_when:   ", m.Route.MuteTimeIntervals, at); err != nil {
+			return err
+		}
+		if err := printIntervals(w, cfg, "active_during:  ", m.Route.ActiveTimeIntervals, at); err != nil {
+			return err
+		}
+
+		muted, interval, err := cfg.Muted(m.Route, at)
+		if err != nil {
+			return err
+		}
+		if muted {
+			fmt.Fprintf(w, "  status:          muted by %s at %s\n", interval, at.Format(time.RFC3339))
+			continue
+		}
+		fmt.Fprintf(w, "  status:          notifies\n")
+		receivers = append(receivers, m.Receiver)
+	}
+
+	if len(receivers) == 0 {
+		fmt.Fprintf(w, "\nReceivers: none (all matching routes are muted)\n")
+		return nil
+	}
+	fmt.Fprintf(w, "\nReceivers: %s\n", strings.Join(receivers, ", "))
+	return nil
+}
+
+// printIntervals lists a route's time intervals with their state at t.
+func printIntervals(w io.Writer, cfg am.Config, label string, names []string, t time.Time) error {
+	if len(names) == 0 {
+		return nil
+	}
+	parts := make([]string, 0, len(names))
+	for _, n := range names {
+		active, err := cfg.IntervalActive(n, t)
+		if err != nil {
+			return err
+		}
+		state := "inactive"
+		if active {
+			state = "active"
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", n, state))
+	}
+	fmt.Fprintf(w, "  %s %s\n", label, strings.Join(parts, ", "))
+	return nil
+}
+
+// describeRoute renders one line for a route: where it came from, its
+// matchers and, if set, its receiver.
+func describeRoute(r *am.Route, root bool) string {
+	source := r.Source
+	switch {
+	case root:
+		source = "root"
+	case source == "":
+		source = "global/root_route.yaml"
+	}
+
+	line := source
+	if len(r.Matchers) > 0 {
+		line += " {" + strings.Join(r.Matchers, ", ") + "}"
+	}
+	if r.Receiver != "" {
+		line += " -> " + r.Receiver
+	}
+	if r.Continue {
+		line += " (continue)"
+	}
+	return line
+}
+
+func formatGroupBy(groupBy []string) string {
+	if len(groupBy) == 0 {
+		return "[] (all alerts in one group)"
+	}
+	return "[" + strings.Join(groupBy, ", ") + "]"
+}
+
+// printRouteTree prints the route tree, marking the routes in matches with *.
+func printRouteTree(w io.Writer, root *am.Route, matches []am.RouteMatch) {
+	reached := map[*am.Route]bool{}
+	for _, m := range matches {
+		for _, r := range m.Chain {
+			reached[r] = true
+		}
+	}
+
+	var walk func(r *am.Route, prefix, branch string, isRoot bool)
+	walk = func(r *am.Route, prefix, branch string, isRoot bool) {
+		mark := ""
+		if reached[r] {
+			mark = "* "
+		}
+		fmt.Fprintf(w, "%s%s%s%s\n", prefix, branch, mark, describeRoute(r, isRoot)+routeSettings(r))
+
+		childPrefix := prefix
+		switch branch {
+		case "├── ":
+			childPrefix += "│   "
+		case "└── ":
+			childPrefix += "    "
+		}
+		for i := range r.Routes {
+			b := "├── "
+			if i == len(r.Routes)-1 {
+				b = "└── "
+			}
+			walk(&r.Routes[i], childPrefix, b, false)
+		}
+	}
+	walk(root, "", "", true)
+}
+
+// routeSettings renders the settings a route sets itself, for the tree.
+func routeSettings(r *am.Route) string {
+	var parts []string
+	if len(r.GroupBy) > 0 {
+		parts = append(parts, "group_by="+formatGroupBy(r.GroupBy))
+	}
+	for _, kv := range [][2]string{
+		{"group_wait", r.GroupWait},
+		{"group_interval", r.GroupInterval},
+		{"repeat_interval", r.RepeatInterval},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+"="+kv[1])
+		}
+	}
+	if len(r.MuteTimeIntervals) > 0 {
+		parts = append(parts, "silence_when=["+strings.Join(r.MuteTimeIntervals, ", ")+"]")
+	}
+	if len(r.ActiveTimeIntervals) > 0 {
+		parts = append(parts, "active_during=["+strings.Join(r.ActiveTimeIntervals, ", ")+"]")
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(parts, " ")
+}
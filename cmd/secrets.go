@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nyambati/fuse/internal/config"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/secrets"
+)
+
+// newSecretsProvider selects the secrets provider. An explicit --secrets flag
+// wins; otherwise .fuse.yaml decides, and a list there becomes a chain.
+func newSecretsProvider(cmd *cobra.Command, o pipelineOptions, root string, cfg config.Config) (secrets.Provider, error) {
+	if cmd.Flags().Changed("secrets") || len(cfg.Secrets) == 0 {
+		return secrets.NewProvider(o.secretsProv, o.secretsConfig)
+	}
+
+	if len(cfg.Secrets) == 1 {
+		src := cfg.Secrets[0]
+		if src.Prefix == "" && src.Regex == "" {
+			configPath := o.secretsConfig
+			if configPath == "" {
+				configPath = projectPath(root, src.Config)
+			}
+			return secrets.NewProvider(src.Provider, configPath)
+		}
+	}
+
+	entries := make([]secrets.ChainEntry, 0, len(cfg.Secrets))
+	for i, src := range cfg.Secrets {
+		prov, err := secrets.NewProvider(src.Provider, projectPath(root, src.Config))
+		if err != nil {
+			return nil, fmt.Errorf("secrets[%d]: %w", i, err)
+		}
+
+		entry := secrets.ChainEntry{
+			Name:        src.Provider,
+			Provider:    prov,
+			Prefix:      src.Prefix,
+			StripPrefix: src.StripPrefix,
+		}
+		if src.Regex != "" {
+			re, err := regexp.Compile(src.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("secrets[%d]: invalid regex %q: %w", i, src.Regex, err)
+			}
+			entry.Regex = re
+		}
+		entries = append(entries, entry)
+	}
+
+	return secrets.NewChainProvider(entries)
+}
+
+// secretResolutionDiags reports which chained provider resolved each key.
+func secretResolutionDiags(prov secrets.Provider) []diag.Diagnostic {
+	chain, ok := prov.(*secrets.ChainProvider)
+	if !ok {
+		return nil
+	}
+
+	var diags []diag.Diagnostic
+	for _, r := range chain.Resolutions() {
+		diags = append(diags, diag.Info(
+			"SECRET_RESOLVED",
+			fmt.Sprintf("secret ${%s} resolved by %s", r.Key, r.Provider),
+			"",
+		))
+	}
+	return diags
+}
+
+// projectPath resolves a path from .fuse.yaml against the project root.
+func projectPath(root, p string) string {
+	if p == "" || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(root, p)
+}
@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nyambati/fuse/internal/config"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/nyambati/fuse/internal/tmpl"
+	"github.com/nyambati/fuse/internal/utils"
+)
+
+func newTemplateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Work with notification templates",
+	}
+	cmd.AddCommand(newTemplateRenderCmd())
+	return cmd
+}
+
+// renderOptions holds the flags of `fuse template render`.
+type renderOptions struct {
+	path        string
+	team        string
+	channel     string
+	alert       string
+	externalURL string
+	groupBy     []string
+}
+
+func newTemplateRenderCmd() *cobra.Command {
+	var o renderOptions
+
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Render a channel's templated fields against sample alerts",
+		Long: `Render executes every templated field of a channel (e.g. a Slack title
+and text) with Alertmanager's template data and function map, and prints
+the result.
+
+Alerts come from --alert, a JSON file holding either a list of alerts or a
+webhook-style payload ({"alerts": [...], "groupLabels": {...}, ...}). Without
+it, one notification is rendered per alerting rule in the team's alerts/
+directory.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplateRender(cmd.OutOrStdout(), o)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.path, "path", ".", "Project path or subdirectory")
+	cmd.Flags().StringVar(&o.team, "team", "", "Team whose channel to render")
+	cmd.Flags().StringVar(&o.channel, "channel", "", "Channel to render; global:<name> for a global channel")
+	cmd.Flags().StringVar(&o.alert, "alert", "", "JSON alert fixture (default: the team's alerting rules)")
+	cmd.Flags().StringVar(&o.externalURL, "external-url", "http://alertmanager.example.com", "Value of .ExternalURL")
+	cmd.Flags().StringSliceVar(&o.groupBy, "group-by", []string{"alertname"}, "Labels that form .GroupLabels when the fixture does not set them")
+	_ = cmd.MarkFlagRequired("team")
+	_ = cmd.MarkFlagRequired("channel")
+
+	return cmd
+}
+
+// notification is one set of template data to render, with a heading.
+type notification struct {
+	heading string
+	data    *tmpl.Data
+}
+
+func runTemplateRender(w io.Writer, o renderOptions) error {
+	root, err := utils.FindProjectRoot(o.path)
+	if err != nil {
+		return fmt.Errorf("not a Fuse project (no .fuse.yaml): %w", err)
+	}
+	cfg, err := config.Load(root)
+	if err != nil {
+		return err
+	}
+
+	// Alertmanager loads every team's templates, so load them all.
+	proj, loadDiags := dsl.LoadProject(root, nil)
+	if len(loadDiags) > 0 {
+		if err := printDiagnostics(os.Stderr, root, loadDiags, false); err != nil {
+			return err
+		}
+	}
+
+	team, ok := findTeam(proj, o.team)
+	if !ok {
+		return fmt.Errorf("team %q not found", o.team)
+	}
+	channel, ok := findChannel(proj, team, o.channel)
+	if !ok {
+		return fmt.Errorf("channel %q not found for team %q", o.channel, o.team)
+	}
+
+	// Files are named as build writes them, so teams' files of the same
+	// name do not replace each other.
+	set := tmpl.NewSet()
+	for _, t := range proj.Teams {
+		for _, f := range t.Templates {
+			if err := set.Add(parse.TemplateFile(t, f), f.Content); err != nil {
+				return fmt.Errorf("%s: %w", f.Path, err)
+			}
+		}
+	}
+
+	receiver := dsl.TargetReceiverName(cfg.Receivers.NamePattern, team.Name, o.channel)
+	notifications, err := renderData(o, team, receiver)
+	if err != nil {
+		return err
+	}
+
+	fields := templatedFields(channel)
+	if len(fields) == 0 {
+		fmt.Fprintf(w, "channel %q has no templated fields; Alertmanager's default templates apply\n", o.channel)
+		return nil
+	}
+
+	failed := 0
+	for i, n := range notifications {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		if n.heading != "" {
+			fmt.Fprintf(w, "# %s\n", n.heading)
+		}
+		for _, f := range fields {
+			out, err := set.ExecuteText(f.text, n.data)
+			if err != nil {
+				fmt.Fprintf(w, "%s: error: %v\n", f.path, err)
+				failed++
+				continue
+			}
+			fmt.Fprintf(w, "%s:\n%s\n", f.path, out)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d field(s) failed to render", failed)
+	}
+	return nil
+}
+
+func findTeam(proj dsl.Project, name string) (dsl.Team, bool) {
+	for _, t := range proj.Teams {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return dsl.Team{}, false
+}
+
+// findChannel resolves a notify target of team to its channel.
+func findChannel(proj dsl.Project, team dsl.Team, target string) (dsl.Channel, bool) {
+	name, global := dsl.ParseTarget(target)
+	chans := team.Channels
+	if global {
+		chans = proj.Channels
+	}
+	for _, ch := range chans {
+		if ch.Name == name {
+			return ch, true
+		}
+	}
+	return dsl.Channel{}, false
+}
+
+// renderData returns the template data to render: the --alert fixture, or
+// one notification per alerting rule of the team.
+func renderData(o renderOptions, team dsl.Team, receiver string) ([]notification, error) {
+	if o.alert != "" {
+		f, err := os.Open(o.alert)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		d, err := tmpl.ReadFixture(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", o.alert, err)
+		}
+		if d.Receiver == "" {
+			d.Receiver = receiver
+		}
+		if d.ExternalURL == "" {
+			d.ExternalURL = o.externalURL
+		}
+		d.Complete(o.groupBy)
+		return []notification{{data: d}}, nil
+	}
+
+	if len(team.Alerts) == 0 {
+		return nil, fmt.Errorf("team %q has no alerting rules in alerts/; pass --alert", team.Name)
+	}
+
+	var out []notification
+	for _, r := range team.Alerts {
+		a := ruleAlert(team, r)
+		out = append(out, notification{
+			heading: fmt.Sprintf("%s (%s)", r.Name, filepath.Join("alerts", filepath.Base(r.Pos.File))),
+			data:    tmpl.NewData(receiver, tmpl.Alerts{a}, o.groupBy, o.externalURL),
+		})
+	}
+	return out, nil
+}
+
+// ruleAlert builds the alert a rule would fire: the rule's labels plus
+// alertname and the team's ownership labels, so it reaches the team's routes.
+// Annotations are used verbatim; Prometheus would expand their templates.
+func ruleAlert(team dsl.Team, r dsl.AlertRule) tmpl.Alert {
+	labels := tmpl.KV{}
+	for _, m := range team.OwnershipMatchers() {
+		if m.Op == "=" {
+			labels[m.Label] = m.Value
+		}
+	}
+	for k, v := range r.Labels {
+		labels[k] = v
+	}
+	labels["alertname"] = r.Name
+
+	annotations := tmpl.KV{}
+	for k, v := range r.Annotations {
+		annotations[k] = v
+	}
+
+	return tmpl.Alert{
+		Labels:      labels,
+		Annotations: annotations,
+		StartsAt:    time.Now().Add(-5 * time.Minute).Truncate(time.Second),
+	}
+}
+
+// templatedField is a channel config string that contains template actions.
+type templatedField struct {
+	path string // e.g. configs[0].title
+	text string
+}
+
+// templatedFields lists the templated strings of a channel's configs, in
+// config order and then sorted by key.
+func templatedFields(ch dsl.Channel) []templatedField {
+	var out []templatedField
+	for i, c := range ch.Configs {
+		collectTemplated(fmt.Sprintf("configs[%d]", i), c, &out)
+	}
+	return out
+}
+
+func collectTemplated(path string, v any, out *[]templatedField) {
+	switch x := v.(type) {
+	case string:
+		if strings.Contains(x, "{{") {
+			*out = append(*out, templatedField{path: path, text: x})
+		}
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			collectTemplated(path+"."+k, x[k], out)
+		}
+	case []any:
+		for i, e := range x {
+			collectTemplated(fmt.Sprintf("%s[%d]", path, i), e, out)
+		}
+	}
+}
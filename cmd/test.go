@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/nyambati/fuse/internal/routetest"
+)
+
+func newTestCmd() *cobra.Command {
+	var (
+		opts  pipelineOptions
+		at    string
+		junit string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Run the routing tests in each team's tests.yaml",
+		Long: `Test builds the Alertmanager config and dispatches every alert listed in
+teams/<name>/tests.yaml through its route tree, following Alertmanager's
+matching, continue and fallthrough rules and muting routes whose time
+intervals apply at the test's timestamp. It fails when an alert does not
+reach exactly the expected receivers.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			now := time.Now()
+			if at != "" {
+				t, err := time.Parse(time.RFC3339, at)
+				if err != nil {
+					return fmt.Errorf("invalid --at %q: expected RFC 3339, e.g. 2024-06-01T10:00:00Z", at)
+				}
+				now = t
+			}
+
+			res, err := runPipeline(cmd, opts)
+			if err != nil {
+				return err
+			}
+			if err := printDiagnostics(os.Stderr, res.root, res.diags, false); err != nil {
+				return err
+			}
+			if err := exitError(res.exit); err != nil {
+				return fmt.Errorf("%w; not running tests", err)
+			}
+
+			results := routetest.Run(res.amc, res.proj, res.config.Receivers.NamePattern, now)
+			if len(results) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "no tests found (add teams/<name>/tests.yaml)")
+				return nil
+			}
+
+			failed := routetest.WriteText(cmd.OutOrStdout(), results)
+
+			if junit != "" {
+				f, err := os.Create(junit)
+				if err != nil {
+					return fmt.Errorf("junit output: %w", err)
+				}
+				defer f.Close()
+				if err := routetest.WriteJUnit(f, results); err != nil {
+					return fmt.Errorf("junit output: %w", err)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d routing test(s) failed", failed)
+			}
+			return nil
+		},
+	}
+
+	opts.bindFlags(cmd)
+	cmd.Flags().StringVar(&at, "at", "", "Evaluate time intervals at this RFC 3339 time when a test sets none (default: now)")
+	cmd.Flags().StringVar(&junit, "junit", "", "Also write results as JUnit XML to this file")
+
+	return cmd
+}
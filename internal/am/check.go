@@ -1,19 +1,117 @@
 package am
 
-import "github.com/nyambati/fuse/internal/diag"
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 
-// "bytes"
-// "os/exec"
+	"github.com/nyambati/fuse/internal/diag"
+)
 
 // CheckWithAmtool optionally validates the rendered Alertmanager config with amtool.
-// MVP: if amtoolPath == "", return no diagnostics. We'll implement this later.
-func CheckWithAmtool(_ Config, amtoolPath string) []diag.Diagnostic {
+// If amtoolPath == "", it returns no diagnostics. Otherwise the config and
+// files (e.g. templates, keyed by path relative to the config) are written
+// to a temporary directory and `amtool check-config` is run on it; its
+// output is mapped onto AMTOOL_* diagnostics. A missing binary or a non-zero
+// exit status is reported with AMTOOL_NOT_FOUND / AMTOOL_EXIT_STATUS, which
+// the caller treats as an external tool failure.
+func CheckWithAmtool(c Config, files map[string][]byte, amtoolPath string) []diag.Diagnostic {
 	if amtoolPath == "" {
 		return nil
 	}
-	// Placeholder stub; real implementation will:
-	// - marshal Config to YAML
-	// - exec amtool check-config <tmpfile>
-	// - parse stdout/stderr into diagnostics
-	return nil
+
+	bin, err := exec.LookPath(amtoolPath)
+	if err != nil {
+		return []diag.Diagnostic{diag.Error(
+			"AMTOOL_NOT_FOUND",
+			fmt.Sprintf("amtool not found at %q: %v", amtoolPath, err),
+			"",
+		)}
+	}
+
+	data, err := Marshal(c)
+	if err != nil {
+		return []diag.Diagnostic{diag.Error("AMTOOL_MARSHAL", err.Error(), "")}
+	}
+
+	dir, err := os.MkdirTemp("", "fuse-amtool-")
+	if err != nil {
+		return []diag.Diagnostic{diag.Error("AMTOOL_EXEC_FAILED", fmt.Sprintf("create temp dir: %v", err), "")}
+	}
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "alertmanager.yaml")
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		return []diag.Diagnostic{diag.Error("AMTOOL_EXEC_FAILED", fmt.Sprintf("write temp config: %v", err), "")}
+	}
+	if err := WriteFiles(dir, files); err != nil {
+		return []diag.Diagnostic{diag.Error("AMTOOL_EXEC_FAILED", fmt.Sprintf("write temp files: %v", err), "")}
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(bin, "check-config", file)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	diags := parseAmtoolOutput(stdout.String()+"\n"+stderr.String(), file)
+
+	var exitErr *exec.ExitError
+	switch {
+	case runErr == nil:
+	case errors.As(runErr, &exitErr):
+		diags = append(diags, diag.Error(
+			"AMTOOL_EXIT_STATUS",
+			fmt.Sprintf("amtool check-config exited with status %d", exitErr.ExitCode()),
+			"",
+		))
+	default:
+		diags = append(diags, diag.Error(
+			"AMTOOL_EXEC_FAILED",
+			fmt.Sprintf("failed to run amtool: %v", runErr),
+			"",
+		))
+	}
+
+	return diags
+}
+
+// parseAmtoolOutput maps amtool check-config output onto diagnostics.
+//
+//	Checking '/tmp/x/alertmanager.yaml'  FAILED: undefined receiver "x" used in route
+//	amtool: error: failed to validate 1 file(s)
+//
+// Progress lines ("Checking ... SUCCESS", "Found:", " - 2 receivers") are dropped.
+func parseAmtoolOutput(out, file string) []diag.Diagnostic {
+	var diags []diag.Diagnostic
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(strings.ReplaceAll(line, file, "alertmanager.yaml"))
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case strings.Contains(line, "FAILED:"):
+			msg := strings.TrimSpace(line[strings.Index(line, "FAILED:")+len("FAILED:"):])
+			diags = append(diags, diag.Error("AMTOOL_CHECK_FAILED", "amtool: "+msg, ""))
+		case strings.HasPrefix(line, "amtool: error:"):
+			msg := strings.TrimSpace(strings.TrimPrefix(line, "amtool: error:"))
+			diags = append(diags, diag.Error("AMTOOL_ERROR", "amtool: "+msg, ""))
+		case strings.Contains(line, "level=warn"), strings.HasPrefix(strings.ToLower(line), "warning"):
+			diags = append(diags, diag.Warn("AMTOOL_WARNING", "amtool: "+line, ""))
+		case strings.HasPrefix(line, "Checking "),
+			strings.HasPrefix(line, "Found:"),
+			strings.HasPrefix(line, "- "):
+			// progress output
+		default:
+			diags = append(diags, diag.Info("AMTOOL_OUTPUT", "amtool: "+line, ""))
+		}
+	}
+
+	return diags
 }
@@ -0,0 +1,73 @@
+package am_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAmtool writes a shell script standing in for amtool.
+func fakeAmtool(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake amtool requires a POSIX shell")
+	}
+	bin := filepath.Join(t.TempDir(), "amtool")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0o755))
+	return bin
+}
+
+func codes(t *testing.T, c am.Config, amtool string) []string {
+	t.Helper()
+	var out []string
+	for _, d := range am.CheckWithAmtool(c, nil, amtool) {
+		out = append(out, d.Code)
+	}
+	return out
+}
+
+func TestCheckWithAmtool(t *testing.T) {
+	cfg := am.Config{
+		Receivers: []am.Receiver{{Name: "default"}},
+		Route:     am.Route{Receiver: "default"},
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		assert.Empty(t, am.CheckWithAmtool(cfg, nil, ""))
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		assert.Equal(t, []string{"AMTOOL_NOT_FOUND"}, codes(t, cfg, filepath.Join(t.TempDir(), "nope")))
+	})
+
+	t.Run("success", func(t *testing.T) {
+		bin := fakeAmtool(t, `[ "$1" = "check-config" ] || exit 9
+grep -q "receiver: default" "$2" || exit 8
+echo "Checking '$2'  SUCCESS"
+echo "Found:"
+echo " - global config"
+echo " - route"
+echo " - 1 receivers"
+`)
+		assert.Empty(t, codes(t, cfg, bin))
+	})
+
+	t.Run("invalid config", func(t *testing.T) {
+		bin := fakeAmtool(t, `echo "Checking '$2'  FAILED: undefined receiver \"pager\" used in route"
+echo ""
+echo "amtool: error: failed to validate 1 file(s)" >&2
+exit 1
+`)
+		diags := am.CheckWithAmtool(cfg, nil, bin)
+		require.Len(t, diags, 3)
+		assert.Equal(t, "AMTOOL_CHECK_FAILED", diags[0].Code)
+		assert.Equal(t, `amtool: undefined receiver "pager" used in route`, diags[0].Message)
+		assert.Equal(t, "AMTOOL_ERROR", diags[1].Code)
+		assert.Equal(t, "AMTOOL_EXIT_STATUS", diags[2].Code)
+	})
+}
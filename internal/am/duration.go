@@ -0,0 +1,50 @@
+package am
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var durationRe = regexp.MustCompile(`^(([0-9]+)y)?(([0-9]+)w)?(([0-9]+)d)?(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?$`)
+
+// ParseDuration parses a duration in the Prometheus format Alertmanager uses
+// (e.g. "30s", "1h30m", "1d", "2w"). Units must appear from largest to smallest.
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("empty duration string")
+	}
+
+	m := durationRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("not a valid duration string: %q", s)
+	}
+
+	units := []time.Duration{
+		365 * 24 * time.Hour, // y
+		7 * 24 * time.Hour,   // w
+		24 * time.Hour,       // d
+		time.Hour,            // h
+		time.Minute,          // m
+		time.Second,          // s
+		time.Millisecond,     // ms
+	}
+
+	var d time.Duration
+	for i, unit := range units {
+		n := m[2*i+2]
+		if n == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(n, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("not a valid duration string: %q", s)
+		}
+		d += time.Duration(v) * unit
+	}
+	return d, nil
+}
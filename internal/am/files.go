@@ -0,0 +1,173 @@
+package am
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ManifestName is the file, inside a managed directory, that lists the files
+// fuse wrote there. A managed directory, such as the templates directory next
+// to the rendered config, may also hold files fuse did not write; those are
+// never modified or removed.
+const ManifestName = ".fuse-manifest"
+
+// WriteManaged writes files, keyed by slash-separated paths relative to dir,
+// into the managed directory sub of dir; every key must lie under sub. Files
+// are staged in a temporary directory inside sub and renamed into place, so a
+// reader never sees a partly written file, and are added to sub's manifest
+// before they are moved. Nothing is removed: call PruneManaged once whatever
+// referenced the previous files has been replaced.
+func WriteManaged(dir, sub string, files map[string][]byte) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if !managedPath(sub, name) {
+			return fmt.Errorf("refusing to write %s outside %s", name, sub)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	root := filepath.Join(dir, filepath.FromSlash(sub))
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", root, err)
+	}
+
+	// Record the new files first, so they are pruned later even if this
+	// build stops half way.
+	listed, err := readManifest(root, sub)
+	if err != nil {
+		return err
+	}
+	if err := writeManifest(root, append(listed, names...)); err != nil {
+		return err
+	}
+
+	stage, err := os.MkdirTemp(root, ".fuse-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory in %s: %w", root, err)
+	}
+	defer os.RemoveAll(stage)
+
+	for i, name := range names {
+		staged := filepath.Join(stage, strconv.Itoa(i))
+		if err := os.WriteFile(staged, files[name], 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", staged, err)
+		}
+	}
+	for i, name := range names {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+		}
+		if err := os.Rename(filepath.Join(stage, strconv.Itoa(i)), target); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// PruneManaged removes the files listed in the manifest of the managed
+// directory sub of dir that are not in files, along with the directories that
+// leaves empty, and then lists just files in the manifest.
+func PruneManaged(dir, sub string, files map[string][]byte) error {
+	root := filepath.Join(dir, filepath.FromSlash(sub))
+	listed, err := readManifest(root, sub)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range listed {
+		if _, keep := files[name]; keep {
+			continue
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.Remove(target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("failed to remove %s: %w", target, err)
+		}
+		// Remove parents left empty, up to the managed directory itself;
+		// os.Remove fails on a directory that still holds anything.
+		for d := filepath.Dir(target); d != root && strings.HasPrefix(d, root); d = filepath.Dir(d) {
+			if os.Remove(d) != nil {
+				break
+			}
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	return writeManifest(root, names)
+}
+
+// managedPath reports whether the slash-separated name lies under sub.
+func managedPath(sub, name string) bool {
+	return filepath.IsLocal(filepath.FromSlash(name)) && strings.HasPrefix(path.Clean(name), path.Clean(sub)+"/")
+}
+
+// readManifest returns the files listed in the manifest of root, the managed
+// directory sub. Entries outside sub are ignored, so a damaged manifest cannot
+// make fuse remove files elsewhere.
+func readManifest(root, sub string) ([]string, error) {
+	f, err := os.Open(filepath.Join(root, ManifestName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	defer f.Close()
+
+	var names []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		if name := strings.TrimSpace(sc.Text()); managedPath(sub, name) {
+			names = append(names, name)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return names, nil
+}
+
+// writeManifest replaces the manifest of root with the sorted, unique names.
+func writeManifest(root string, names []string) error {
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("# Files written by fuse build; do not edit.\n")
+	for i, name := range names {
+		if i > 0 && names[i-1] == name {
+			continue
+		}
+		b.WriteString(name + "\n")
+	}
+
+	tmp, err := os.CreateTemp(root, ManifestName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(b.String()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(root, ManifestName)); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
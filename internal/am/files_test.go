@@ -0,0 +1,107 @@
+package am_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tree lists the files under dir as slash paths, with their contents.
+func tree(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	out := map[string]string{}
+	require.NoError(t, filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		out[filepath.ToSlash(rel)] = string(data)
+		return nil
+	}))
+	return out
+}
+
+func write(t *testing.T, dir string, files map[string][]byte) {
+	t.Helper()
+	require.NoError(t, am.WriteManaged(dir, "templates", files))
+	require.NoError(t, am.PruneManaged(dir, "templates", files))
+}
+
+// Files in the templates directory that fuse did not write, such as the
+// templates of an existing Alertmanager install, survive every build.
+func TestWriteManaged_KeepsForeignFiles(t *testing.T) {
+	dir := t.TempDir()
+	foreign := map[string]string{
+		"alertmanager.yml":          "route: {}\n",
+		"templates/custom.tmpl":     "{{ define \"custom\" }}{{ end }}",
+		"templates/ops/pager.tmpl":  "{{ define \"pager\" }}{{ end }}",
+		"templates/payments/x.tmpl": "{{ define \"x\" }}{{ end }}",
+	}
+	for name, content := range foreign {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+
+	write(t, dir, map[string][]byte{
+		"templates/payments/slack.tmpl": []byte("v1"),
+		"templates/search/email.tmpl":   []byte("v1"),
+	})
+	write(t, dir, map[string][]byte{
+		"templates/payments/slack.tmpl": []byte("v2"),
+	})
+
+	got := tree(t, dir)
+	assert.Contains(t, got["templates/"+am.ManifestName], "templates/payments/slack.tmpl\n")
+	delete(got, "templates/"+am.ManifestName)
+
+	want := map[string]string{"templates/payments/slack.tmpl": "v2"}
+	for name, content := range foreign {
+		want[name] = content
+	}
+	assert.Equal(t, want, got)
+
+	// search/ held only fuse's files and is removed with them.
+	_, err := os.Stat(filepath.Join(dir, "templates", "search"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}
+
+func TestWriteManaged_ManifestOutsideDir(t *testing.T) {
+	dir := t.TempDir()
+	victim := filepath.Join(dir, "alertmanager.yml")
+	require.NoError(t, os.WriteFile(victim, []byte("route: {}\n"), 0o644))
+
+	// A manifest naming files outside the managed directory is ignored.
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", am.ManifestName),
+		[]byte("alertmanager.yml\ntemplates/../alertmanager.yml\n"), 0o644))
+	write(t, dir, map[string][]byte{"templates/a/x.tmpl": []byte("x")})
+	assert.FileExists(t, victim)
+
+	err := am.WriteManaged(dir, "templates", map[string][]byte{"templates/../alertmanager.yml": nil})
+	assert.ErrorContains(t, err, "outside templates")
+}
+
+func TestWriteManaged_NoStagingLeftBehind(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, map[string][]byte{"templates/a/x.tmpl": []byte("x")})
+
+	entries, err := os.ReadDir(filepath.Join(dir, "templates"))
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{am.ManifestName, "a"}, names)
+}
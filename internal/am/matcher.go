@@ -0,0 +1,97 @@
+package am
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matcher operators, as in Alertmanager.
+const (
+	MatchEqual     = "="
+	MatchNotEqual  = "!="
+	MatchRegexp    = "=~"
+	MatchNotRegexp = "!~"
+)
+
+// Matcher is a parsed Alertmanager label matcher such as severity=~"crit|page".
+type Matcher struct {
+	Name  string
+	Op    string
+	Value string
+
+	re *regexp.Regexp
+}
+
+var matcherRe = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*(=~|=|!=|!~)\s*((?s).*?)\s*$`)
+
+// NewMatcher builds a matcher, compiling the value for regex operators.
+// Like Alertmanager, regexes are anchored at both ends.
+func NewMatcher(name, op, value string) (Matcher, error) {
+	m := Matcher{Name: name, Op: op, Value: value}
+	switch op {
+	case MatchEqual, MatchNotEqual:
+	case MatchRegexp, MatchNotRegexp:
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return Matcher{}, fmt.Errorf("invalid regex %q in matcher %q: %w", value, name, err)
+		}
+		m.re = re
+	default:
+		return Matcher{}, fmt.Errorf("invalid operator %q in matcher %q", op, name)
+	}
+	return m, nil
+}
+
+// ParseMatcher parses a single matcher string in Alertmanager's syntax:
+// a label name, one of = != =~ !~, and a value that is either double-quoted
+// (with \" \\ \n escapes) or bare. Surrounding braces are accepted.
+func ParseMatcher(s string) (Matcher, error) {
+	in := strings.TrimSpace(s)
+	if strings.HasPrefix(in, "{") && strings.HasSuffix(in, "}") {
+		in = strings.TrimSpace(in[1 : len(in)-1])
+	}
+
+	m := matcherRe.FindStringSubmatch(in)
+	if m == nil {
+		return Matcher{}, fmt.Errorf("bad matcher format: %s", s)
+	}
+
+	value := m[3]
+	if strings.HasPrefix(value, `"`) {
+		if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+			return Matcher{}, fmt.Errorf("matcher value %s is missing a closing quote", value)
+		}
+		unq, err := strconv.Unquote(value)
+		if err != nil {
+			return Matcher{}, fmt.Errorf("invalid quoted value %s in matcher %q: %w", value, m[1], err)
+		}
+		value = unq
+	} else if strings.HasSuffix(value, `"`) {
+		return Matcher{}, fmt.Errorf("matcher value %s is missing an opening quote", value)
+	}
+
+	return NewMatcher(m[1], m[2], value)
+}
+
+// Matches reports whether a label value satisfies the matcher.
+// A missing label is matched as the empty string, as in Alertmanager.
+func (m Matcher) Matches(v string) bool {
+	switch m.Op {
+	case MatchEqual:
+		return v == m.Value
+	case MatchNotEqual:
+		return v != m.Value
+	case MatchRegexp:
+		return m.re != nil && m.re.MatchString(v)
+	case MatchNotRegexp:
+		return m.re == nil || !m.re.MatchString(v)
+	}
+	return false
+}
+
+// String renders the matcher in the form Alertmanager expects in config files.
+func (m Matcher) String() string {
+	return fmt.Sprintf("%s%s%s", m.Name, m.Op, strconv.Quote(m.Value))
+}
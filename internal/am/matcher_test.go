@@ -0,0 +1,102 @@
+package am_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		in      string
+		name    string
+		op      string
+		value   string
+		wantErr bool
+	}{
+		{in: `severity="critical"`, name: "severity", op: "=", value: "critical"},
+		{in: `severity = "critical"`, name: "severity", op: "=", value: "critical"},
+		{in: `severity=critical`, name: "severity", op: "=", value: "critical"},
+		{in: `env!="prod"`, name: "env", op: "!=", value: "prod"},
+		{in: `severity=~"crit|page"`, name: "severity", op: "=~", value: "crit|page"},
+		{in: `team!~"pay.*"`, name: "team", op: "!~", value: "pay.*"},
+		{in: `{alertname="Foo"}`, name: "alertname", op: "=", value: "Foo"},
+		{in: `summary="say \"hi\""`, name: "summary", op: "=", value: `say "hi"`},
+		{in: `severity=""`, name: "severity", op: "=", value: ""},
+		{in: `severity`, wantErr: true},
+		{in: `1abc="x"`, wantErr: true},
+		{in: `severity=~"("`, wantErr: true},
+		{in: `severity="critical`, wantErr: true},
+		{in: `severity=critical"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			m, err := am.ParseMatcher(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, m.Name)
+			assert.Equal(t, tt.op, m.Op)
+			assert.Equal(t, tt.value, m.Value)
+		})
+	}
+}
+
+func TestMatcherMatches(t *testing.T) {
+	tests := []struct {
+		matcher string
+		value   string
+		want    bool
+	}{
+		{`severity="critical"`, "critical", true},
+		{`severity="critical"`, "warning", false},
+		{`severity!="critical"`, "warning", true},
+		{`severity=~"crit|page"`, "page", true},
+		{`severity=~"crit"`, "critical", false}, // anchored
+		{`severity!~"crit.*"`, "warning", true},
+		{`env=""`, "", true}, // missing label
+	}
+
+	for _, tt := range tests {
+		m, err := am.ParseMatcher(tt.matcher)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, m.Matches(tt.value), "%s vs %q", tt.matcher, tt.value)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "30s", want: 30 * time.Second},
+		{in: "1h30m", want: 90 * time.Minute},
+		{in: "1d", want: 24 * time.Hour},
+		{in: "2w", want: 14 * 24 * time.Hour},
+		{in: "500ms", want: 500 * time.Millisecond},
+		{in: "", wantErr: true},
+		{in: "5 minutes", wantErr: true},
+		{in: "30m1h", wantErr: true},
+		{in: "1.5h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := am.ParseDuration(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
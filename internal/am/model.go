@@ -2,6 +2,7 @@ package am
 
 type Config struct {
 	Global        map[string]any    `yaml:"global,omitempty"`
+	Templates     []string          `yaml:"templates,omitempty"`
 	Receivers     []Receiver        `yaml:"receivers,omitempty"`
 	Route         Route             `yaml:"route,omitempty"`
 	InhibitRules  []InhibitRule     `yaml:"inhibit_rules,omitempty"`
@@ -9,28 +10,46 @@ type Config struct {
 }
 
 type Receiver struct {
-	Name            string           `yaml:"name"`
-	SlackConfigs    []map[string]any `yaml:"slack_configs,omitempty"`
-	WebhookConfigs  []map[string]any `yaml:"webhook_configs,omitempty"`
-	OpsgenieConfigs []map[string]any `yaml:"opsgenie_configs,omitempty"`
+	Name              string           `yaml:"name"`
+	EmailConfigs      []map[string]any `yaml:"email_configs,omitempty"`
+	PagerdutyConfigs  []map[string]any `yaml:"pagerduty_configs,omitempty"`
+	PushoverConfigs   []map[string]any `yaml:"pushover_configs,omitempty"`
+	SlackConfigs      []map[string]any `yaml:"slack_configs,omitempty"`
+	SNSConfigs        []map[string]any `yaml:"sns_configs,omitempty"`
+	VictorOpsConfigs  []map[string]any `yaml:"victorops_configs,omitempty"`
+	WebhookConfigs    []map[string]any `yaml:"webhook_configs,omitempty"`
+	OpsgenieConfigs   []map[string]any `yaml:"opsgenie_configs,omitempty"`
+	WechatConfigs     []map[string]any `yaml:"wechat_configs,omitempty"`
+	TelegramConfigs   []map[string]any `yaml:"telegram_configs,omitempty"`
+	WebexConfigs      []map[string]any `yaml:"webex_configs,omitempty"`
+	DiscordConfigs    []map[string]any `yaml:"discord_configs,omitempty"`
+	MSTeamsConfigs    []map[string]any `yaml:"msteams_configs,omitempty"`
+	MSTeamsV2Configs  []map[string]any `yaml:"msteamsv2_configs,omitempty"`
+	JiraConfigs       []map[string]any `yaml:"jira_configs,omitempty"`
+	RocketchatConfigs []map[string]any `yaml:"rocketchat_configs,omitempty"`
 }
 
 type Route struct {
-	Receiver       string   `yaml:"receiver,omitempty"`
-	GroupBy        []string `yaml:"group_by,omitempty"`
-	GroupWait      string   `yaml:"group_wait,omitempty"`
-	GroupInterval  string   `yaml:"group_interval,omitempty"`
-	RepeatInterval string   `yaml:"repeat_interval,omitempty"`
-	Matchers       []string `yaml:"matchers,omitempty"`
-	Continue       bool     `yaml:"continue,omitempty"`
-	TimeIntervals  []string `yaml:"time_intervals,omitempty"`
-	Routes         []Route  `yaml:"routes,omitempty"`
+	Receiver            string   `yaml:"receiver,omitempty"`
+	GroupBy             []string `yaml:"group_by,omitempty"`
+	GroupWait           string   `yaml:"group_wait,omitempty"`
+	GroupInterval       string   `yaml:"group_interval,omitempty"`
+	RepeatInterval      string   `yaml:"repeat_interval,omitempty"`
+	Matchers            []string `yaml:"matchers,omitempty"`
+	Continue            bool     `yaml:"continue,omitempty"`
+	MuteTimeIntervals   []string `yaml:"mute_time_intervals,omitempty"`
+	ActiveTimeIntervals []string `yaml:"active_time_intervals,omitempty"`
+	Routes              []Route  `yaml:"routes,omitempty"`
+
+	// Source names the DSL element the route was built from, e.g.
+	// "team/payments flows[0]". It is not part of the rendered config.
+	Source string `yaml:"-"`
 }
 
 type InhibitRule struct {
-	SourceMatchers map[string]string `yaml:"source_matchers,omitempty"`
-	TargetMatchers map[string]string `yaml:"target_matchers,omitempty"`
-	Equal          []string          `yaml:"equal,omitempty"`
+	SourceMatchers []string `yaml:"source_matchers,omitempty"`
+	TargetMatchers []string `yaml:"target_matchers,omitempty"`
+	Equal          []string `yaml:"equal,omitempty"`
 }
 
 type TimeIntervalSet struct {
@@ -0,0 +1,23 @@
+package am
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// SetConfigs sets the receiver's configs stored under the YAML key, e.g.
+// "slack_configs". It fails if Receiver has no such key.
+func (r *Receiver) SetConfigs(key string, cfgs []map[string]any) error {
+	v := reflect.ValueOf(r).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if tag != key || !strings.HasSuffix(key, "_configs") {
+			continue
+		}
+		v.Field(i).Set(reflect.ValueOf(cfgs))
+		return nil
+	}
+	return fmt.Errorf("alertmanager receivers have no %q", key)
+}
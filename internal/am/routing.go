@@ -0,0 +1,166 @@
+package am
+
+import (
+	"fmt"
+	"time"
+)
+
+// RouteMatch is a route an alert was dispatched to.
+type RouteMatch struct {
+	Route    *Route
+	Path     string   // e.g. route.routes[0].routes[1]
+	Receiver string   // the route's receiver, inherited from its parent if unset
+	Chain    []*Route // the routes from the root down to Route
+}
+
+// Match returns the routes an alert with labels is dispatched to, following
+// Alertmanager's semantics: children are tried in order, the first matching
+// child stops the search unless it sets continue, and a route none of whose
+// children match handles the alert itself. r is the root route and always
+// matches.
+func (r *Route) Match(labels map[string]string) ([]RouteMatch, error) {
+	return r.match(labels, "route", r.Receiver, nil)
+}
+
+func (r *Route) match(labels map[string]string, path, receiver string, parents []*Route) ([]RouteMatch, error) {
+	if r.Receiver != "" {
+		receiver = r.Receiver
+	}
+
+	if len(parents) > 0 {
+		ok, err := matchesAll(r.Matchers, labels)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	chain := append(append([]*Route{}, parents...), r)
+
+	var out []RouteMatch
+	for i := range r.Routes {
+		child := &r.Routes[i]
+		m, err := child.match(labels, fmt.Sprintf("%s.routes[%d]", path, i), receiver, chain)
+		if err != nil {
+			return nil, err
+		}
+		if len(m) == 0 {
+			continue
+		}
+		out = append(out, m...)
+		if !child.Continue {
+			break
+		}
+	}
+
+	if len(out) == 0 {
+		out = append(out, RouteMatch{Route: r, Path: path, Receiver: receiver, Chain: chain})
+	}
+	return out, nil
+}
+
+// Alertmanager's defaults for routes that set no timing anywhere in their chain.
+const (
+	DefaultGroupWait      = "30s"
+	DefaultGroupInterval  = "5m"
+	DefaultRepeatInterval = "4h"
+)
+
+// RouteOptions are the grouping and timing settings in effect for a route.
+type RouteOptions struct {
+	GroupBy        []string
+	GroupWait      string
+	GroupInterval  string
+	RepeatInterval string
+}
+
+// Options resolves the settings the matched route inherits: each comes from
+// the nearest route in the chain that sets it, else Alertmanager's default.
+func (m RouteMatch) Options() RouteOptions {
+	o := RouteOptions{
+		GroupWait:      DefaultGroupWait,
+		GroupInterval:  DefaultGroupInterval,
+		RepeatInterval: DefaultRepeatInterval,
+	}
+	for _, r := range m.Chain {
+		if len(r.GroupBy) > 0 {
+			o.GroupBy = r.GroupBy
+		}
+		if r.GroupWait != "" {
+			o.GroupWait = r.GroupWait
+		}
+		if r.GroupInterval != "" {
+			o.GroupInterval = r.GroupInterval
+		}
+		if r.RepeatInterval != "" {
+			o.RepeatInterval = r.RepeatInterval
+		}
+	}
+	return o
+}
+
+// matchesAll reports whether labels satisfy every matcher string.
+func matchesAll(matchers []string, labels map[string]string) (bool, error) {
+	for _, s := range matchers {
+		m, err := ParseMatcher(s)
+		if err != nil {
+			return false, err
+		}
+		if !m.Matches(labels[m.Name]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Muted reports whether notifications of route r are suppressed at t: one of
+// its mute_time_intervals is active, or it has active_time_intervals and none
+// of them is. It also returns the interval responsible. Like Alertmanager,
+// neither list is inherited from parent routes.
+func (c Config) Muted(r *Route, t time.Time) (bool, string, error) {
+	for _, name := range r.MuteTimeIntervals {
+		active, err := c.IntervalActive(name, t)
+		if err != nil {
+			return false, "", err
+		}
+		if active {
+			return true, name, nil
+		}
+	}
+
+	if len(r.ActiveTimeIntervals) == 0 {
+		return false, "", nil
+	}
+	for _, name := range r.ActiveTimeIntervals {
+		active, err := c.IntervalActive(name, t)
+		if err != nil {
+			return false, "", err
+		}
+		if active {
+			return false, "", nil
+		}
+	}
+	return true, r.ActiveTimeIntervals[0], nil
+}
+
+// IntervalActive reports whether the named time interval contains t.
+func (c Config) IntervalActive(name string, t time.Time) (bool, error) {
+	for _, set := range c.TimeIntervals {
+		if set.Name != name {
+			continue
+		}
+		for _, ti := range set.TimeIntervals {
+			ok, err := ti.Contains(t)
+			if err != nil {
+				return false, fmt.Errorf("time interval %q: %w", name, err)
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, fmt.Errorf("time interval %q is not defined", name)
+}
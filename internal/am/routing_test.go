@@ -0,0 +1,144 @@
+package am_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteMatch(t *testing.T) {
+	root := am.Route{
+		Receiver: "default",
+		Routes: []am.Route{
+			{
+				Matchers: []string{`team="payments"`},
+				Receiver: "payments/slack",
+				Routes: []am.Route{
+					{Matchers: []string{`severity="critical"`}, Receiver: "payments/pager", Continue: true},
+					{Matchers: []string{`severity=~"critical|warning"`}},
+				},
+			},
+			{Matchers: []string{`team=~"pay.*"`}, Receiver: "unreachable"},
+			{Matchers: []string{`env="prod"`}, Receiver: "prod", Continue: true},
+			{Matchers: []string{`env="prod"`}, Receiver: "prod-audit"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   []string
+		paths  []string
+	}{
+		{
+			name:   "continue then inherited receiver",
+			labels: map[string]string{"team": "payments", "severity": "critical"},
+			want:   []string{"payments/pager", "payments/slack"},
+			paths:  []string{"route.routes[0].routes[0]", "route.routes[0].routes[1]"},
+		},
+		{
+			name:   "falls through to parent",
+			labels: map[string]string{"team": "payments", "severity": "info"},
+			want:   []string{"payments/slack"},
+			paths:  []string{"route.routes[0]"},
+		},
+		{
+			name:   "sibling continue",
+			labels: map[string]string{"env": "prod"},
+			want:   []string{"prod", "prod-audit"},
+		},
+		{
+			name:   "root fallback",
+			labels: map[string]string{"team": "billing"},
+			want:   []string{"default"},
+			paths:  []string{"route"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := root.Match(tt.labels)
+			require.NoError(t, err)
+
+			var got, paths []string
+			for _, m := range matches {
+				got = append(got, m.Receiver)
+				paths = append(paths, m.Path)
+			}
+			assert.Equal(t, tt.want, got)
+			if tt.paths != nil {
+				assert.Equal(t, tt.paths, paths)
+			}
+		})
+	}
+
+	bad := am.Route{Routes: []am.Route{{Matchers: []string{`severity`}}}}
+	_, err := bad.Match(map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestConfigMuted(t *testing.T) {
+	cfg := am.Config{TimeIntervals: []am.TimeIntervalSet{
+		{Name: "weekends", TimeIntervals: []am.TimeInterval{{Weekdays: []string{"saturday", "sunday"}}}},
+	}}
+	saturday := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		route    am.Route
+		at       time.Time
+		want     bool
+		interval string
+		wantErr  bool
+	}{
+		{name: "mute active", route: am.Route{MuteTimeIntervals: []string{"weekends"}}, at: saturday, want: true, interval: "weekends"},
+		{name: "mute inactive", route: am.Route{MuteTimeIntervals: []string{"weekends"}}, at: monday},
+		{name: "active interval applies", route: am.Route{ActiveTimeIntervals: []string{"weekends"}}, at: saturday},
+		{name: "outside active interval", route: am.Route{ActiveTimeIntervals: []string{"weekends"}}, at: monday, want: true, interval: "weekends"},
+		{name: "undefined interval", route: am.Route{MuteTimeIntervals: []string{"nope"}}, at: monday, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			muted, interval, err := cfg.Muted(&tt.route, tt.at)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, muted)
+			assert.Equal(t, tt.interval, interval)
+		})
+	}
+}
+
+func TestRouteMatchOptions(t *testing.T) {
+	root := am.Route{
+		Receiver: "default",
+		GroupBy:  []string{"alertname"},
+		Routes: []am.Route{{
+			Matchers:       []string{`team="payments"`},
+			RepeatInterval: "1h",
+			Routes: []am.Route{{
+				Matchers:  []string{`severity="critical"`},
+				GroupBy:   []string{"alertname", "cluster"},
+				GroupWait: "10s",
+			}},
+		}},
+	}
+
+	matches, err := root.Match(map[string]string{"team": "payments", "severity": "critical"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Len(t, matches[0].Chain, 3)
+	assert.Equal(t, am.RouteOptions{
+		GroupBy:        []string{"alertname", "cluster"},
+		GroupWait:      "10s",
+		GroupInterval:  am.DefaultGroupInterval,
+		RepeatInterval: "1h",
+	}, matches[0].Options())
+}
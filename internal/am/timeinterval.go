@@ -0,0 +1,146 @@
+package am
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	weekdayNames = map[string]int{
+		"sunday": 0, "monday": 1, "tuesday": 2, "wednesday": 3,
+		"thursday": 4, "friday": 5, "saturday": 6,
+	}
+	monthNames = map[string]int{
+		"january": 1, "february": 2, "march": 3, "april": 4, "may": 5, "june": 6,
+		"july": 7, "august": 8, "september": 9, "october": 10, "november": 11, "december": 12,
+	}
+)
+
+// Contains reports whether t falls inside the interval, with Alertmanager's
+// semantics: every non-empty field must match, each field matches when any
+// of its ranges does, ranges are inclusive except times (whose end is
+// exclusive), and negative days of month count from the end of the month.
+func (ti TimeInterval) Contains(t time.Time) (bool, error) {
+	loc := time.UTC
+	if ti.Location != "" {
+		l, err := time.LoadLocation(ti.Location)
+		if err != nil {
+			return false, fmt.Errorf("invalid location %q: %w", ti.Location, err)
+		}
+		loc = l
+	}
+	t = t.In(loc)
+
+	if len(ti.Times) > 0 {
+		minute := t.Hour()*60 + t.Minute()
+		in := false
+		for _, r := range ti.Times {
+			start, err := parseClock(r.Start)
+			if err != nil {
+				return false, err
+			}
+			end, err := parseClock(r.End)
+			if err != nil {
+				return false, err
+			}
+			if minute >= start && minute < end {
+				in = true
+				break
+			}
+		}
+		if !in {
+			return false, nil
+		}
+	}
+
+	checks := []struct {
+		ranges []string
+		value  int
+		parse  func(string) (int, error)
+	}{
+		{ti.Weekdays, int(t.Weekday()), namedNumber(weekdayNames, 0, 6)},
+		{ti.DaysOfMonth, t.Day(), dayOfMonth(t)},
+		{ti.Months, int(t.Month()), namedNumber(monthNames, 1, 12)},
+		{ti.Years, t.Year(), strconv.Atoi},
+	}
+	for _, c := range checks {
+		if len(c.ranges) == 0 {
+			continue
+		}
+		ok, err := inRanges(c.ranges, c.value, c.parse)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// inRanges reports whether v is inside any of the "a" or "a:b" ranges.
+func inRanges(ranges []string, v int, parse func(string) (int, error)) (bool, error) {
+	for _, r := range ranges {
+		lo, hi, found := strings.Cut(r, ":")
+		if !found {
+			hi = lo
+		}
+		start, err := parse(strings.TrimSpace(lo))
+		if err != nil {
+			return false, err
+		}
+		end, err := parse(strings.TrimSpace(hi))
+		if err != nil {
+			return false, err
+		}
+		if v >= start && v <= end {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// namedNumber parses a value given by name (case-insensitive) or number.
+func namedNumber(names map[string]int, lo, hi int) func(string) (int, error) {
+	return func(s string) (int, error) {
+		if n, ok := names[strings.ToLower(s)]; ok {
+			return n, nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil || n < lo || n > hi {
+			return 0, fmt.Errorf("invalid value %q", s)
+		}
+		return n, nil
+	}
+}
+
+// dayOfMonth parses a day of t's month; -1 is the last day.
+func dayOfMonth(t time.Time) func(string) (int, error) {
+	days := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+	return func(s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil || n == 0 || n < -31 || n > 31 {
+			return 0, fmt.Errorf("invalid day of month %q", s)
+		}
+		if n < 0 {
+			n = days + n + 1
+		}
+		return n, nil
+	}
+}
+
+// parseClock parses "HH:MM" into minutes since midnight; "24:00" is allowed.
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if ok {
+		hh, err1 := strconv.Atoi(h)
+		mm, err2 := strconv.Atoi(m)
+		if err1 == nil && err2 == nil && hh >= 0 && mm >= 0 && mm < 60 && (hh < 24 || hh == 24 && mm == 0) {
+			return hh*60 + mm, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid time %q (expected HH:MM)", s)
+}
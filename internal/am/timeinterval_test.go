@@ -0,0 +1,59 @@
+package am_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeIntervalContains(t *testing.T) {
+	// Friday 2024-05-31 16:30 UTC; Saturday 02:30 in Sydney.
+	at := time.Date(2024, 5, 31, 16, 30, 0, 0, time.UTC)
+
+	hours := func(start, end string) []struct {
+		Start string `yaml:"start_time"`
+		End   string `yaml:"end_time"`
+	} {
+		return []struct {
+			Start string `yaml:"start_time"`
+			End   string `yaml:"end_time"`
+		}{{Start: start, End: end}}
+	}
+
+	tests := []struct {
+		name    string
+		ti      am.TimeInterval
+		want    bool
+		wantErr bool
+	}{
+		{name: "empty matches everything", ti: am.TimeInterval{}, want: true},
+		{name: "weekday range", ti: am.TimeInterval{Weekdays: []string{"Monday:Friday"}}, want: true},
+		{name: "weekday in location", ti: am.TimeInterval{Weekdays: []string{"saturday"}, Location: "Australia/Sydney"}, want: true},
+		{name: "times inside", ti: am.TimeInterval{Times: hours("09:00", "17:00")}, want: true},
+		{name: "end exclusive", ti: am.TimeInterval{Times: hours("09:00", "16:30")}, want: false},
+		{name: "times in location", ti: am.TimeInterval{Times: hours("09:00", "17:00"), Location: "Australia/Sydney"}, want: false},
+		{name: "last day of month", ti: am.TimeInterval{DaysOfMonth: []string{"-1"}}, want: true},
+		{name: "days of month range", ti: am.TimeInterval{DaysOfMonth: []string{"1:7"}}, want: false},
+		{name: "month names and numbers", ti: am.TimeInterval{Months: []string{"january", "5"}}, want: true},
+		{name: "years", ti: am.TimeInterval{Years: []string{"2020:2023"}}, want: false},
+		{name: "all fields must match", ti: am.TimeInterval{Weekdays: []string{"friday"}, Years: []string{"2023"}}, want: false},
+		{name: "bad weekday", ti: am.TimeInterval{Weekdays: []string{"funday"}}, wantErr: true},
+		{name: "bad time", ti: am.TimeInterval{Times: hours("9am", "17:00")}, wantErr: true},
+		{name: "bad location", ti: am.TimeInterval{Location: "Mars/Base"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ti.Contains(at)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package channels
+
+import (
+	"fmt"
+
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// Field helpers for the built-in types.
+
+func str(name, desc string) Field  { return Field{Name: name, Type: String, Description: desc} }
+func num(name, desc string) Field  { return Field{Name: name, Type: Int, Description: desc} }
+func flag(name, desc string) Field { return Field{Name: name, Type: Bool, Description: desc} }
+func list(name, desc string) Field { return Field{Name: name, Type: List, Description: desc} }
+func obj(name, desc string) Field  { return Field{Name: name, Type: Map, Description: desc} }
+func dur(name, desc string) Field  { return Field{Name: name, Type: Duration, Description: desc} }
+
+// secret returns a credential field and its *_file variant.
+func secret(name, desc string) []Field {
+	return []Field{
+		{Name: name, Type: String, Secret: true, Description: desc},
+		{Name: name + "_file", Type: String, Description: "File to read " + name + " from"},
+	}
+}
+
+// common returns the fields every Alertmanager notifier accepts. sendResolved
+// is written as the default so the rendered config states it explicitly;
+// Alertmanager's own default differs between notifiers.
+func common(sendResolved bool) []Field {
+	return []Field{
+		{Name: "send_resolved", Type: Bool, Default: sendResolved, Description: "Notify when alerts resolve"},
+		{
+			Name: "http_config", Type: Map, Description: "HTTP client settings",
+			SecretKeys: []string{"password", "credentials", "bearer_token", "client_secret"},
+		},
+	}
+}
+
+func fields(groups ...any) []Field {
+	var out []Field
+	for _, g := range groups {
+		switch v := g.(type) {
+		case Field:
+			out = append(out, v)
+		case []Field:
+			out = append(out, v...)
+		}
+	}
+	return out
+}
+
+func init() {
+	for _, t := range []Type{
+		{
+			Name:      "discord",
+			ConfigKey: "discord_configs",
+			Required:  [][]string{{"webhook_url", "webhook_url_file"}},
+			Fields: fields(common(true),
+				secret("webhook_url", "Discord webhook URL"),
+				str("title", "Message title template"),
+				str("message", "Message body template"),
+				str("content", "Message content template"),
+				str("username", "Username the message is sent as"),
+				str("avatar_url", "Avatar image URL"),
+			),
+		},
+		{
+			Name:      "email",
+			ConfigKey: "email_configs",
+			Required:  [][]string{{"to"}},
+			Fields: fields(common(false),
+				str("to", "Comma-separated recipient addresses"),
+				str("from", "Sender address"),
+				str("smarthost", "SMTP host:port"),
+				str("hello", "Hostname sent in HELO"),
+				str("auth_username", "SMTP username"),
+				secret("auth_password", "SMTP password"),
+				secret("auth_secret", "SMTP CRAM-MD5 secret"),
+				str("auth_identity", "SMTP identity"),
+				flag("require_tls", "Require STARTTLS"),
+				obj("tls_config", "TLS settings"),
+				str("html", "HTML body template"),
+				str("text", "Text body template"),
+				obj("headers", "Extra email headers"),
+			),
+		},
+		{
+			Name:      "jira",
+			ConfigKey: "jira_configs",
+			Required:  [][]string{{"project"}, {"issue_type"}},
+			Fields: fields(common(true),
+				str("api_url", "Jira API URL"),
+				str("project", "Project key"),
+				str("issue_type", "Issue type"),
+				str("summary", "Summary template"),
+				str("description", "Description template"),
+				str("priority", "Priority template"),
+				list("labels", "Issue labels"),
+				obj("fields", "Custom issue fields"),
+				str("reopen_transition", "Transition used to reopen issues"),
+				str("resolve_transition", "Transition used to resolve issues"),
+				str("wont_fix_resolution", "Resolution that stops reopening"),
+				dur("reopen_duration", "How long a resolved issue may be reopened"),
+			),
+		},
+		{
+			Name:      "msteams",
+			ConfigKey: "msteams_configs",
+			Required:  [][]string{{"webhook_url", "webhook_url_file"}},
+			Fields: fields(common(true),
+				secret("webhook_url", "Incoming webhook URL"),
+				str("title", "Title template"),
+				str("summary", "Summary template"),
+				str("text", "Text template"),
+			),
+		},
+		{
+			Name:      "msteamsv2",
+			ConfigKey: "msteamsv2_configs",
+			Required:  [][]string{{"webhook_url", "webhook_url_file"}},
+			Fields: fields(common(true),
+				secret("webhook_url", "Workflows webhook URL"),
+				str("title", "Title template"),
+				str("text", "Text template"),
+			),
+		},
+		{
+			Name:      "opsgenie",
+			ConfigKey: "opsgenie_configs",
+			Fields: fields(common(true),
+				secret("api_key", "Opsgenie API key (or global opsgenie_api_key)"),
+				str("api_url", "Opsgenie API URL"),
+				str("message", "Alert message template"),
+				str("description", "Description template"),
+				str("source", "Source template"),
+				obj("details", "Extra alert details"),
+				str("entity", "Entity template"),
+				list("responders", "Responders to notify"),
+				str("actions", "Comma-separated actions"),
+				str("tags", "Comma-separated tags"),
+				str("note", "Note template"),
+				str("priority", "Priority template (P1-P5)"),
+				flag("update_alerts", "Update existing alerts on change"),
+			),
+		},
+		{
+			Name:      "pagerduty",
+			ConfigKey: "pagerduty_configs",
+			Required:  [][]string{{"routing_key", "routing_key_file", "service_key", "service_key_file"}},
+			Fields: fields(common(true),
+				secret("routing_key", "Events API v2 integration key"),
+				secret("service_key", "Events API v1 integration key"),
+				str("url", "PagerDuty API URL"),
+				str("client", "Client name"),
+				str("client_url", "Client URL"),
+				str("description", "Description template"),
+				obj("details", "Extra incident details"),
+				list("images", "Images to attach"),
+				list("links", "Links to attach"),
+				str("source", "Source template"),
+				str("severity", "Severity template"),
+				str("class", "Class template"),
+				str("component", "Component template"),
+				str("group", "Group template"),
+				dur("timeout", "Request timeout"),
+			),
+		},
+		{
+			Name:      "pushover",
+			ConfigKey: "pushover_configs",
+			Required:  [][]string{{"user_key", "user_key_file"}, {"token", "token_file"}},
+			Fields: fields(common(true),
+				secret("user_key", "Recipient user key"),
+				secret("token", "Application token"),
+				str("title", "Title template"),
+				str("message", "Message template"),
+				str("url", "Supplementary URL"),
+				str("url_title", "Supplementary URL title"),
+				str("device", "Target device"),
+				str("sound", "Notification sound"),
+				str("priority", "Priority template"),
+				dur("retry", "Emergency retry interval"),
+				dur("expire", "Emergency retry expiry"),
+				dur("ttl", "Message time to live"),
+				flag("html", "Enable HTML formatting"),
+				flag("monospace", "Enable monospace formatting"),
+			),
+		},
+		{
+			Name:      "rocketchat",
+			ConfigKey: "rocketchat_configs",
+			Fields: fields(common(false),
+				str("api_url", "Rocket.Chat URL"),
+				str("channel", "Channel or user to post to"),
+				secret("token_id", "API token ID (or global rocketchat_token_id)"),
+				secret("token", "API token (or global rocketchat_token)"),
+				str("color", "Attachment color"),
+				str("emoji", "Emoji for the message"),
+				str("icon_url", "Icon URL"),
+				str("text", "Text template"),
+				str("title", "Title template"),
+				str("title_link", "Title link template"),
+				list("fields", "Attachment fields"),
+				flag("short_fields", "Display fields side by side"),
+				str("image_url", "Image URL"),
+				str("thumb_url", "Thumbnail URL"),
+				flag("link_names", "Link user and channel names"),
+				list("actions", "Message actions"),
+			),
+		},
+		{
+			Name:      "slack",
+			ConfigKey: "slack_configs",
+			Required:  [][]string{{"channel"}},
+			Fields: fields(common(false),
+				secret("api_url", "Slack webhook URL (or global slack_api_url)"),
+				str("channel", "Channel or user to post to"),
+				str("username", "Username the message is sent as"),
+				str("color", "Attachment color"),
+				str("title", "Title template"),
+				str("title_link", "Title link template"),
+				str("pretext", "Pretext template"),
+				str("text", "Text template"),
+				list("fields", "Attachment fields"),
+				flag("short_fields", "Display fields side by side"),
+				str("footer", "Footer template"),
+				str("fallback", "Fallback text template"),
+				str("callback_id", "Callback ID"),
+				str("icon_emoji", "Icon emoji"),
+				str("icon_url", "Icon URL"),
+				str("image_url", "Image URL"),
+				str("thumb_url", "Thumbnail URL"),
+				flag("link_names", "Link user and channel names"),
+				list("mrkdwn_in", "Fields rendered as markdown"),
+				list("actions", "Message actions"),
+				dur("timeout", "Request timeout"),
+			),
+		},
+		{
+			Name:      "sns",
+			ConfigKey: "sns_configs",
+			Required:  [][]string{{"topic_arn", "target_arn", "phone_number"}},
+			Fields: fields(common(true),
+				str("api_url", "SNS API URL"),
+				Field{Name: "sigv4", Type: Map, Description: "AWS SigV4 settings", SecretKeys: []string{"secret_key"}},
+				str("topic_arn", "Topic ARN"),
+				str("target_arn", "Mobile platform endpoint ARN"),
+				str("phone_number", "Phone number for SMS"),
+				str("subject", "Subject template"),
+				str("message", "Message template"),
+				obj("attributes", "Message attributes"),
+			),
+		},
+		{
+			Name:      "telegram",
+			ConfigKey: "telegram_configs",
+			Required:  [][]string{{"chat_id"}, {"bot_token", "bot_token_file"}},
+			Fields: fields(common(true),
+				str("api_url", "Telegram API URL"),
+				secret("bot_token", "Bot token"),
+				num("chat_id", "Chat ID"),
+				num("message_thread_id", "Forum topic ID"),
+				str("message", "Message template"),
+				flag("disable_notifications", "Send silently"),
+				str("parse_mode", "Markdown, MarkdownV2 or HTML"),
+			),
+			Check: checkTelegramParseMode,
+		},
+		{
+			Name:      "victorops",
+			ConfigKey: "victorops_configs",
+			Required:  [][]string{{"routing_key"}},
+			Fields: fields(common(true),
+				secret("api_key", "VictorOps API key (or global victorops_api_key)"),
+				str("api_url", "VictorOps API URL"),
+				str("routing_key", "Routing key"),
+				str("message_type", "Message type template"),
+				str("entity_display_name", "Entity display name template"),
+				str("state_message", "State message template"),
+				str("monitoring_tool", "Monitoring tool template"),
+				obj("custom_fields", "Extra fields"),
+			),
+		},
+		{
+			Name:      "webex",
+			ConfigKey: "webex_configs",
+			Required:  [][]string{{"room_id"}},
+			Fields: fields(common(true),
+				str("api_url", "Webex API URL"),
+				str("room_id", "Room ID"),
+				str("message", "Message template"),
+			),
+		},
+		{
+			Name:      "webhook",
+			ConfigKey: "webhook_configs",
+			Required:  [][]string{{"url", "url_file"}},
+			Fields: fields(common(true),
+				str("url", "Endpoint URL"),
+				str("url_file", "File to read url from"),
+				num("max_alerts", "Maximum alerts per message (0 for all)"),
+				dur("timeout", "Request timeout"),
+			),
+		},
+		{
+			Name:      "wechat",
+			ConfigKey: "wechat_configs",
+			Fields: fields(common(false),
+				secret("api_secret", "API secret (or global wechat_api_secret)"),
+				str("api_url", "WeChat API URL"),
+				str("corp_id", "Corp ID"),
+				str("message", "Message template"),
+				str("message_type", "text or markdown"),
+				str("agent_id", "Agent ID"),
+				str("to_user", "Users to notify"),
+				str("to_party", "Parties to notify"),
+				str("to_tag", "Tags to notify"),
+			),
+		},
+	} {
+		Register(t)
+	}
+}
+
+func checkTelegramParseMode(ch dsl.Channel, idx int, cfg map[string]any) []diag.Diagnostic {
+	mode, _ := cfg["parse_mode"].(string)
+	switch mode {
+	case "", "Markdown", "MarkdownV2", "HTML":
+		return nil
+	}
+	return []diag.Diagnostic{ch.ConfigPos(idx, "parse_mode").Locate(diag.Diagnostic{
+		Level:   diag.LevelError,
+		Code:    "CHANNEL_TELEGRAM_PARSE_MODE",
+		Message: fmt.Sprintf("telegram channel %q configs[%d] has unknown parse_mode %q; use Markdown, MarkdownV2 or HTML", ch.Name, idx, mode),
+	})}
+}
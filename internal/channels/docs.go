@@ -0,0 +1,145 @@
+package channels
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes a reference of every registered channel type.
+func WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("# Channel types\n\n")
+	b.WriteString("Fields marked secret must be given as `${...}` placeholders or through their `_file` variant.\n")
+
+	for _, t := range Types() {
+		fmt.Fprintf(&b, "\n## %s\n\n", t.Name)
+		fmt.Fprintf(&b, "Rendered as `%s`.", t.ConfigKey)
+		if len(t.Required) > 0 {
+			groups := make([]string, len(t.Required))
+			for i, alts := range t.Required {
+				groups[i] = strings.Join(backtick(alts), " or ")
+			}
+			fmt.Fprintf(&b, " Requires %s.", strings.Join(groups, "; "))
+		}
+		b.WriteString("\n\n")
+		b.WriteString("| Field | Type | Secret | Default | Description |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, f := range t.Fields {
+			secret, def := "", ""
+			if f.Secret {
+				secret = "yes"
+			}
+			if f.Default != nil {
+				def = fmt.Sprintf("`%v`", f.Default)
+			}
+			fmt.Fprintf(&b, "| `%s` | %s | %s | %s | %s |\n", f.Name, f.Type, secret, def, f.Description)
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Schema returns a JSON Schema for channels.yaml files.
+func Schema() map[string]any {
+	var (
+		names []any
+		rules []any
+	)
+	for _, t := range Types() {
+		names = append(names, t.Name)
+		rules = append(rules, map[string]any{
+			"if":   map[string]any{"properties": map[string]any{"type": map[string]any{"const": t.Name}}},
+			"then": map[string]any{"properties": map[string]any{"configs": map[string]any{"type": "array", "items": configSchema(t)}}},
+		})
+	}
+
+	channel := map[string]any{
+		"type":     "object",
+		"required": []any{"name", "type"},
+		"properties": map[string]any{
+			"name":    map[string]any{"type": "string"},
+			"type":    map[string]any{"enum": names},
+			"configs": map[string]any{"type": "array", "items": map[string]any{"type": "object"}},
+		},
+		"additionalProperties": false,
+		"allOf":                rules,
+	}
+
+	return map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "Fuse channels.yaml",
+		"type":    "object",
+		"properties": map[string]any{
+			"channels": map[string]any{"type": "array", "items": channel},
+		},
+	}
+}
+
+func configSchema(t Type) map[string]any {
+	props := map[string]any{}
+	for _, f := range t.Fields {
+		p := fieldSchema(f)
+		if f.Description != "" {
+			p["description"] = f.Description
+		}
+		if f.Default != nil {
+			p["default"] = f.Default
+		}
+		props[f.Name] = p
+	}
+
+	s := map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+
+	var required []any
+	for _, alts := range t.Required {
+		if len(alts) == 1 {
+			required = append(required, map[string]any{"required": []any{alts[0]}})
+			continue
+		}
+		var anyOf []any
+		for _, a := range alts {
+			anyOf = append(anyOf, map[string]any{"required": []any{a}})
+		}
+		required = append(required, map[string]any{"anyOf": anyOf})
+	}
+	if len(required) > 0 {
+		s["allOf"] = required
+	}
+	return s
+}
+
+// placeholderSchema matches a string holding a ${...} placeholder, accepted
+// in place of any non-string value.
+var placeholderSchema = map[string]any{"type": "string", "pattern": `\$\{[^}]+\}`}
+
+func fieldSchema(f Field) map[string]any {
+	jsonType := map[FieldType]string{
+		Int:  "integer",
+		Bool: "boolean",
+		List: "array",
+		Map:  "object",
+	}
+	switch f.Type {
+	case String:
+		return map[string]any{"type": "string"}
+	case Duration:
+		return map[string]any{"type": "string", "pattern": `^(0|(([0-9]+)(y|w|d|h|m|s|ms))+)$|\$\{`}
+	default:
+		return map[string]any{"anyOf": []any{map[string]any{"type": jsonType[f.Type]}, placeholderSchema}}
+	}
+}
+
+func backtick(ss []string) []string {
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = "`" + s + "`"
+	}
+	return out
+}
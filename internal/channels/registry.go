@@ -0,0 +1,325 @@
+// Package channels is the registry of channel types. Each type declares how
+// a channel is rendered into an Alertmanager receiver and which fields its
+// configs accept; the parser, the validators and the generated docs and
+// schema all read from it.
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/secrets"
+)
+
+// FieldType is the YAML type of a config field.
+type FieldType string
+
+const (
+	String   FieldType = "string"
+	Int      FieldType = "int"
+	Bool     FieldType = "bool"
+	List     FieldType = "list"
+	Map      FieldType = "map"
+	Duration FieldType = "duration"
+)
+
+// Field describes one key of a channel config.
+type Field struct {
+	Name string
+	Type FieldType
+	// Secret marks credentials; they must be given as ${...} placeholders or
+	// through a *_file field.
+	Secret bool
+	// SecretKeys lists the keys nested anywhere in a map field that hold
+	// credentials, e.g. basic_auth.password in http_config.
+	SecretKeys []string
+	// Default is written into the rendered config when the field is unset.
+	Default     any
+	Description string
+}
+
+// Type describes a channel type such as slack or webhook.
+type Type struct {
+	Name string
+	// ConfigKey is the receiver key the configs are rendered under, e.g. slack_configs.
+	ConfigKey string
+	// Required lists groups of alternative fields; each config must set one
+	// field of every group (e.g. url or url_file). Fields that Alertmanager
+	// can take from the global section are not required.
+	Required [][]string
+	Fields   []Field
+	// Check adds type-specific checks on a single config.
+	Check func(ch dsl.Channel, idx int, cfg map[string]any) []diag.Diagnostic
+}
+
+var registry = map[string]Type{}
+
+// Register adds or replaces a channel type.
+func Register(t Type) {
+	registry[t.Name] = t
+}
+
+// Lookup returns the channel type registered under name (case-insensitive).
+func Lookup(name string) (Type, bool) {
+	t, ok := registry[strings.ToLower(strings.TrimSpace(name))]
+	return t, ok
+}
+
+// Types returns all registered channel types sorted by name.
+func Types() []Type {
+	out := make([]Type, 0, len(registry))
+	for _, t := range registry {
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Field returns the declared field called name.
+func (t Type) Field(name string) (Field, bool) {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// IsSecret reports whether key holds a credential for this type.
+func (t Type) IsSecret(key string) bool {
+	f, ok := t.Field(key)
+	return ok && f.Secret
+}
+
+// IsSecretKey reports whether key, nested in the value of field, holds a
+// credential for this type.
+func (t Type) IsSecretKey(field, key string) bool {
+	f, ok := t.Field(field)
+	if !ok {
+		return false
+	}
+	for _, k := range f.SecretKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyDefaults returns a copy of cfg with unset fields set to their defaults.
+func (t Type) ApplyDefaults(cfg map[string]any) map[string]any {
+	out := make(map[string]any, len(cfg))
+	for k, v := range cfg {
+		out[k] = v
+	}
+	for _, f := range t.Fields {
+		if f.Default == nil {
+			continue
+		}
+		if _, ok := out[f.Name]; !ok {
+			out[f.Name] = f.Default
+		}
+	}
+	return out
+}
+
+// FieldError reports a config field whose value cannot be converted to the
+// field's type.
+type FieldError struct {
+	Config int // index of the config
+	Field  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("configs[%d].%s: %v", e.Config, e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Build sets the receiver's configs for this type, with defaults applied.
+// Placeholders always resolve to strings, so string values of int and bool
+// fields are converted, e.g. chat_id: ${TG_CHAT} is rendered as a number.
+// Values that do not convert are left as they are and returned as
+// *FieldError, joined; the receiver is set either way.
+func (t Type) Build(r *am.Receiver, cfgs []map[string]any) error {
+	var errs []error
+	out := make([]map[string]any, 0, len(cfgs))
+	for i, cfg := range cfgs {
+		cfg = t.ApplyDefaults(cfg)
+		for _, f := range t.Fields {
+			v, err := convert(f, cfg[f.Name])
+			if err != nil {
+				errs = append(errs, &FieldError{Config: i, Field: f.Name, Err: err})
+				continue
+			}
+			if v != nil {
+				cfg[f.Name] = v
+			}
+		}
+		out = append(out, cfg)
+	}
+	if err := r.SetConfigs(t.ConfigKey, out); err != nil {
+		return err
+	}
+	return errors.Join(errs...)
+}
+
+// convert parses a string value of an int or bool field. It returns nil when
+// there is nothing to convert, including strings with unresolved placeholders.
+func convert(f Field, v any) (any, error) {
+	s, ok := v.(string)
+	if !ok || secrets.HasPlaceholders(s) {
+		return nil, nil
+	}
+	switch f.Type {
+	case Int:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %s", s, f.Type)
+		}
+		return n, nil
+	case Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid %s", s, f.Type)
+		}
+		return b, nil
+	}
+	return nil, nil
+}
+
+// Validate checks every config of ch against the type: required fields, field
+// types, unknown fields and conflicting key/key_file pairs.
+func (t Type) Validate(ch dsl.Channel) []diag.Diagnostic {
+	var diags []diag.Diagnostic
+	code := func(suffix string) string {
+		return fmt.Sprintf("CHANNEL_%s_%s", strings.ToUpper(t.Name), suffix)
+	}
+
+	for i, cfg := range ch.Configs {
+		for _, alts := range t.Required {
+			if !hasAnyField(cfg, alts) {
+				diags = append(diags, ch.ConfigPos(i, "").Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    code("NO_" + strings.ToUpper(alts[0])),
+					Message: fmt.Sprintf("%s channel %q configs[%d] missing %s", t.Name, ch.Name, i, quoteAlternatives(alts)),
+				}))
+			}
+		}
+
+		keys := make([]string, 0, len(cfg))
+		for k := range cfg {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			f, ok := t.Field(k)
+			if !ok {
+				diags = append(diags, ch.ConfigPos(i, k).Locate(diag.Diagnostic{
+					Level:   diag.LevelWarn,
+					Code:    code("UNKNOWN_FIELD"),
+					Message: fmt.Sprintf("%s channel %q configs[%d] has unknown field %q", t.Name, ch.Name, i, k),
+				}))
+				continue
+			}
+			if err := checkType(f, cfg[k]); err != nil {
+				diags = append(diags, ch.ConfigPos(i, k).Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    code("FIELD_TYPE"),
+					Message: fmt.Sprintf("%s channel %q configs[%d].%s: %v", t.Name, ch.Name, i, k, err),
+				}))
+			}
+			if _, both := cfg[k+"_file"]; both {
+				diags = append(diags, ch.ConfigPos(i, k+"_file").Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    code("FIELD_CONFLICT"),
+					Message: fmt.Sprintf("%s channel %q configs[%d] sets both %q and %q", t.Name, ch.Name, i, k, k+"_file"),
+				}))
+			}
+		}
+
+		if t.Check != nil {
+			diags = append(diags, t.Check(ch, i, cfg)...)
+		}
+	}
+	return diags
+}
+
+// checkType reports whether v fits the field's type. Strings holding ${...}
+// placeholders are accepted for every type, as they are resolved and converted
+// at build time.
+func checkType(f Field, v any) error {
+	if s, ok := v.(string); ok && secrets.HasPlaceholders(s) {
+		return nil
+	}
+	ok := true
+	switch f.Type {
+	case String:
+		_, ok = v.(string)
+	case Int:
+		switch v.(type) {
+		case int, int64, uint64:
+		default:
+			ok = false
+		}
+	case Bool:
+		_, ok = v.(bool)
+	case List:
+		_, ok = v.([]any)
+	case Map:
+		_, ok = v.(map[string]any)
+	case Duration:
+		s, isStr := v.(string)
+		if !isStr {
+			ok = false
+			break
+		}
+		if _, err := am.ParseDuration(s); err != nil {
+			return err
+		}
+	}
+	if !ok {
+		return fmt.Errorf("expected %s, got %T", f.Type, v)
+	}
+	return nil
+}
+
+// hasAnyField reports whether cfg sets one of keys to a non-empty value.
+func hasAnyField(cfg map[string]any, keys []string) bool {
+	for _, k := range keys {
+		switch val := cfg[k].(type) {
+		case nil:
+		case string:
+			if strings.TrimSpace(val) != "" {
+				return true
+			}
+		case []any:
+			if len(val) > 0 {
+				return true
+			}
+		case map[string]any:
+			if len(val) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func quoteAlternatives(keys []string) string {
+	q := make([]string, len(keys))
+	for i, k := range keys {
+		q[i] = fmt.Sprintf("'%s'", k)
+	}
+	return strings.Join(q, " or ")
+}
@@ -0,0 +1,200 @@
+package channels_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/channels"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRegistry_BuildsEveryType(t *testing.T) {
+	want := []string{
+		"discord", "email", "jira", "msteams", "msteamsv2", "opsgenie", "pagerduty", "pushover",
+		"rocketchat", "slack", "sns", "telegram", "victorops", "webex", "webhook", "wechat",
+	}
+
+	var got []string
+	for _, ct := range channels.Types() {
+		got = append(got, ct.Name)
+
+		var r am.Receiver
+		require.NoError(t, ct.Build(&r, []map[string]any{{}}), ct.Name)
+		out, err := am.Marshal(am.Config{Receivers: []am.Receiver{r}})
+		require.NoError(t, err)
+		assert.Contains(t, string(out), ct.ConfigKey+":", ct.Name)
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestType_ApplyDefaults(t *testing.T) {
+	slack, ok := channels.Lookup("Slack")
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]any{"channel": "#ops", "send_resolved": false},
+		slack.ApplyDefaults(map[string]any{"channel": "#ops"}))
+	assert.Equal(t, map[string]any{"send_resolved": true},
+		slack.ApplyDefaults(map[string]any{"send_resolved": true}))
+	assert.True(t, slack.IsSecret("api_url"))
+	assert.False(t, slack.IsSecret("channel"))
+}
+
+func TestType_BuildConvertsResolvedValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		typ      string
+		cfg      map[string]any
+		want     map[string]any
+		wantErrs []string
+	}{
+		{
+			name: "int and bool strings are converted",
+			typ:  "telegram",
+			cfg:  map[string]any{"chat_id": "-100123", "bot_token": "t", "send_resolved": "false"},
+			want: map[string]any{"chat_id": int64(-100123), "bot_token": "t", "send_resolved": false},
+		},
+		{
+			name: "native values are kept",
+			typ:  "webhook",
+			cfg:  map[string]any{"url": "https://example.com", "max_alerts": 5},
+			want: map[string]any{"url": "https://example.com", "max_alerts": 5, "send_resolved": true},
+		},
+		{
+			name: "unresolved placeholders are left alone",
+			typ:  "webhook",
+			cfg:  map[string]any{"url": "https://example.com", "max_alerts": "${MAX}"},
+			want: map[string]any{"url": "https://example.com", "max_alerts": "${MAX}", "send_resolved": true},
+		},
+		{
+			name:     "values that do not convert are errors",
+			typ:      "webhook",
+			cfg:      map[string]any{"url": "https://example.com", "max_alerts": "lots", "send_resolved": "maybe"},
+			want:     map[string]any{"url": "https://example.com", "max_alerts": "lots", "send_resolved": "maybe"},
+			wantErrs: []string{"send_resolved", "max_alerts"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, ok := channels.Lookup(tt.typ)
+			require.True(t, ok)
+
+			var r am.Receiver
+			err := ct.Build(&r, []map[string]any{tt.cfg})
+
+			var fields []string
+			if err != nil {
+				for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+					var fe *channels.FieldError
+					require.ErrorAs(t, e, &fe)
+					fields = append(fields, fe.Field)
+				}
+			}
+			assert.Equal(t, tt.wantErrs, fields)
+
+			out, err := am.Marshal(am.Config{Receivers: []am.Receiver{r}})
+			require.NoError(t, err)
+			var doc struct {
+				Receivers []map[string]any `yaml:"receivers"`
+			}
+			require.NoError(t, yaml.Unmarshal(out, &doc))
+			got := doc.Receivers[0][ct.ConfigKey].([]any)[0].(map[string]any)
+			for k, v := range tt.want {
+				assert.EqualValues(t, v, got[k], k)
+			}
+		})
+	}
+}
+
+func TestType_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		channel   dsl.Channel
+		wantCodes []string
+	}{
+		{
+			name: "valid webhook",
+			channel: dsl.Channel{Name: "hook", Type: "webhook", Configs: []map[string]any{
+				{"url": "https://example.com", "max_alerts": 10, "timeout": "10s", "send_resolved": false},
+			}},
+		},
+		{
+			name: "placeholders satisfy any type",
+			channel: dsl.Channel{Name: "tg", Type: "telegram", Configs: []map[string]any{
+				{"chat_id": "${TG_CHAT}", "bot_token": "${TG_TOKEN}"},
+			}},
+		},
+		{
+			name: "missing required field",
+			channel: dsl.Channel{Name: "pd", Type: "pagerduty", Configs: []map[string]any{
+				{"severity": "critical"},
+			}},
+			wantCodes: []string{"CHANNEL_PAGERDUTY_NO_ROUTING_KEY"},
+		},
+		{
+			name: "unknown field",
+			channel: dsl.Channel{Name: "slack", Type: "slack", Configs: []map[string]any{
+				{"channel": "#ops", "webhook_url": "${URL}"},
+			}},
+			wantCodes: []string{"CHANNEL_SLACK_UNKNOWN_FIELD"},
+		},
+		{
+			name: "wrong field types",
+			channel: dsl.Channel{Name: "hook", Type: "webhook", Configs: []map[string]any{
+				{"url": "https://example.com", "max_alerts": "ten", "timeout": "soon"},
+			}},
+			wantCodes: []string{"CHANNEL_WEBHOOK_FIELD_TYPE", "CHANNEL_WEBHOOK_FIELD_TYPE"},
+		},
+		{
+			name: "value and file both set",
+			channel: dsl.Channel{Name: "hook", Type: "webhook", Configs: []map[string]any{
+				{"url": "https://example.com", "url_file": "/etc/am/url"},
+			}},
+			wantCodes: []string{"CHANNEL_WEBHOOK_FIELD_CONFLICT"},
+		},
+		{
+			name: "type-specific check",
+			channel: dsl.Channel{Name: "tg", Type: "telegram", Configs: []map[string]any{
+				{"chat_id": 1, "bot_token": "${TG_TOKEN}", "parse_mode": "markdown"},
+			}},
+			wantCodes: []string{"CHANNEL_TELEGRAM_PARSE_MODE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, ok := channels.Lookup(tt.channel.Type)
+			require.True(t, ok)
+
+			var codes []string
+			for _, d := range ct.Validate(tt.channel) {
+				codes = append(codes, d.Code)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+}
+
+func TestDocs(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, channels.WriteMarkdown(&buf))
+
+	schema := channels.Schema()
+	items := schema["properties"].(map[string]any)["channels"].(map[string]any)["items"].(map[string]any)
+	names := items["properties"].(map[string]any)["type"].(map[string]any)["enum"].([]any)
+
+	for _, ct := range channels.Types() {
+		assert.Contains(t, buf.String(), "## "+ct.Name+"\n")
+		assert.Contains(t, names, ct.Name)
+	}
+}
+
+func TestReceiver_SetConfigsUnknownKey(t *testing.T) {
+	var r am.Receiver
+	assert.Error(t, r.SetConfigs("carrier_pigeon_configs", nil))
+	assert.Error(t, r.SetConfigs("name", nil))
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the name of the project configuration file at the project root.
+const FileName = ".fuse.yaml"
+
+// DefaultOutput is used when .fuse.yaml does not declare build.output.
+const DefaultOutput = "dist/alertmanager.yaml"
+
+// Config mirrors the contents of .fuse.yaml.
+type Config struct {
+	Secrets    Secrets    `yaml:"secrets"`
+	Build      Build      `yaml:"build"`
+	SecretScan SecretScan `yaml:"secret_scan"`
+	Receivers  Receivers  `yaml:"receivers"`
+	Defaults   Defaults   `yaml:"defaults"`
+}
+
+// Secrets is the ordered list of secrets providers. In .fuse.yaml it is
+// either a single provider name:
+//
+//	secrets: env
+//
+// or a list consulted in order, each with an optional routing rule:
+//
+//	secrets:
+//	  - provider: vault
+//	    config: secrets/vault.yaml
+//	    prefix: VAULT_
+//	    strip_prefix: true
+//	  - provider: env
+type Secrets []SecretSource
+
+// SecretSource is one entry of the secrets list.
+type SecretSource struct {
+	Provider string `yaml:"provider"`
+	// Config is the provider config file, relative to the project root.
+	Config string `yaml:"config"`
+	// Prefix and Regex restrict which keys are offered to this provider.
+	Prefix      string `yaml:"prefix"`
+	Regex       string `yaml:"regex"`
+	StripPrefix bool   `yaml:"strip_prefix"`
+}
+
+// UnmarshalYAML accepts a provider name or a list of sources.
+func (s *Secrets) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if node.Value == "" {
+			*s = nil
+			return nil
+		}
+		*s = Secrets{{Provider: node.Value}}
+		return nil
+	}
+
+	var list []SecretSource
+	if err := node.Decode(&list); err != nil {
+		return err
+	}
+	*s = list
+	return nil
+}
+
+// SecretScan configures the secret-leak checks on channel configs.
+type SecretScan struct {
+	Allowlist []SecretAllow `yaml:"allowlist"`
+}
+
+// SecretAllow acknowledges a secret-scan finding. Empty fields match anything;
+// Field matches either the full path (configs[0].api_url) or the key (api_url).
+type SecretAllow struct {
+	Team    string `yaml:"team"`
+	Channel string `yaml:"channel"`
+	Field   string `yaml:"field"`
+	Reason  string `yaml:"reason"`
+}
+
+// Receivers controls how team channels are named in the generated config.
+type Receivers struct {
+	// NamePattern may use {team} and {channel}; it defaults to
+	// "{team}/{channel}". Use "{channel}" to turn namespacing off.
+	NamePattern string `yaml:"name_pattern"`
+}
+
+// Build holds settings for `fuse build`.
+type Build struct {
+	Output string `yaml:"output"`
+}
+
+// Defaults holds optional defaults for CLI flags.
+type Defaults struct {
+	Verbose bool `yaml:"verbose"`
+	Quiet   bool `yaml:"quiet"`
+}
+
+// Load reads .fuse.yaml from the project root and fills in defaults.
+func Load(root string) (Config, error) {
+	var cfg Config
+
+	path := filepath.Join(root, FileName)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(b, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if cfg.Build.Output == "" {
+		cfg.Build.Output = DefaultOutput
+	}
+
+	return cfg, nil
+}
+
+// OutputPath returns the build output path resolved against the project root.
+func (c Config) OutputPath(root string) string {
+	if filepath.IsAbs(c.Build.Output) {
+		return c.Build.Output
+	}
+	return filepath.Join(root, c.Build.Output)
+}
@@ -0,0 +1,66 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantSecrets config.Secrets
+		wantOutput  string
+	}{
+		{
+			name:        "scalar provider and defaults",
+			content:     "secrets: env\n",
+			wantSecrets: config.Secrets{{Provider: "env"}},
+			wantOutput:  config.DefaultOutput,
+		},
+		{
+			name: "provider chain",
+			content: `
+secrets:
+  - provider: vault
+    config: secrets/vault.yaml
+    prefix: VAULT_
+    strip_prefix: true
+  - provider: ssm
+    regex: ^AWS_
+  - provider: env
+build:
+  output: out/am.yaml
+`,
+			wantSecrets: config.Secrets{
+				{Provider: "vault", Config: "secrets/vault.yaml", Prefix: "VAULT_", StripPrefix: true},
+				{Provider: "ssm", Regex: "^AWS_"},
+				{Provider: "env"},
+			},
+			wantOutput: "out/am.yaml",
+		},
+		{
+			name:       "no secrets",
+			content:    "build:\n  output: dist/am.yaml\n",
+			wantOutput: "dist/am.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(root, config.FileName), []byte(tt.content), 0o644))
+
+			cfg, err := config.Load(root)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSecrets, cfg.Secrets)
+			assert.Equal(t, tt.wantOutput, cfg.Build.Output)
+			assert.Equal(t, filepath.Join(root, tt.wantOutput), cfg.OutputPath(root))
+		})
+	}
+}
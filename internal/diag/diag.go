@@ -16,6 +16,7 @@ type Diagnostic struct {
 	Message string `json:"message"`
 	File    string `json:"file,omitempty"`
 	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
 }
 
 func Error(code, msg, file string) Diagnostic {
@@ -1,15 +1,18 @@
 package dsl
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
-	"github.com/nyambati/fuse/internal/am"
+	"gopkg.in/yaml.v3"
+
 	"github.com/nyambati/fuse/internal/diag"
-	"github.com/stretchr/testify/assert/yaml"
 )
 
 //
@@ -29,11 +32,11 @@ func LoadProject(root string, teamFilter []string) (Project, []diag.Diagnostic)
 	}
 
 	if err := loadGlobal(root, &p); err != nil {
-		diags = append(diags, diag.Diagnostic{
+		diags = append(diags, errorPos(err).Locate(diag.Diagnostic{
 			Level:   diag.LevelError,
 			Code:    "LOAD_GLOBAL",
 			Message: fmt.Sprintf("failed to load global configuration: %v", err),
-		})
+		}))
 	}
 
 	// Discover teams directory
@@ -94,12 +97,12 @@ func LoadProject(root string, teamFilter []string) (Project, []diag.Diagnostic)
 		}
 
 		if err := loadTeam(teamPath, &team); err != nil {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, errorPos(err).Locate(diag.Diagnostic{
 				Level:   diag.LevelError,
 				Code:    "READ_TEAM",
 				Message: fmt.Sprintf("failed to read team %s: %v", name, err),
 				File:    teamPath,
-			})
+			}))
 		} else {
 			p.Teams = append(p.Teams, team)
 		}
@@ -136,12 +139,41 @@ func unmarshalYamlFile(filePath string, out interface{}, optional bool) error {
 	}
 
 	if err := yaml.Unmarshal(b, out); err != nil {
-		return fmt.Errorf("failed to parse %s: %w", filePath, err)
+		return &parseError{file: filePath, err: err}
 	}
 
 	return nil
 }
 
+// parseError is a YAML file that failed to decode.
+type parseError struct {
+	file string
+	err  error
+}
+
+func (e *parseError) Error() string {
+	return fmt.Sprintf("failed to parse %s: %v", e.file, e.err)
+}
+
+func (e *parseError) Unwrap() error { return e.err }
+
+// yamlLineRe finds the line yaml.v3 (and our unmarshalers) report, as in
+// "yaml: line 3: did not find expected key".
+var yamlLineRe = regexp.MustCompile(`\bline (\d+):`)
+
+// errorPos returns the file and line a load error points at, if known.
+func errorPos(err error) Pos {
+	var pe *parseError
+	if !errors.As(err, &pe) {
+		return Pos{}
+	}
+	p := Pos{File: pe.file}
+	if m := yamlLineRe.FindStringSubmatch(pe.err.Error()); m != nil {
+		p.Line, _ = strconv.Atoi(m[1])
+	}
+	return p
+}
+
 func loadGlobal(root string, p *Project) error {
 	// global/global.yaml
 	var raw map[string]any
@@ -157,82 +189,180 @@ func loadGlobal(root string, p *Project) error {
 		}
 	}
 
+	// global/channels.yaml (optional)
+	var chWrapped struct {
+		Channels []Channel `yaml:"channels"`
+	}
+	chFile := filepath.Join(root, "global", "channels.yaml")
+	if err := unmarshalYamlFile(chFile, &chWrapped, true); err != nil {
+		return err
+	}
+	stampFile(chWrapped.Channels, chFile)
+	p.Channels = append(p.Channels, chWrapped.Channels...)
+
 	// global/silence_windows.yaml
 	var swWrapped struct {
 		SilenceWindows []SilenceWindow `yaml:"silence_windows"`
 	}
-	if err := unmarshalYamlFile(filepath.Join(root, "global", "silence_windows.yaml"), &swWrapped, true); err != nil {
+	swFile := filepath.Join(root, "global", "silence_windows.yaml")
+	if err := unmarshalYamlFile(swFile, &swWrapped, true); err != nil {
 		return err
 	}
+	stampFile(swWrapped.SilenceWindows, swFile)
 	p.SilenceWindows = append(p.SilenceWindows, swWrapped.SilenceWindows...)
 
 	// global/inhibitors.yaml (optional)
 	var ihWrapped struct {
 		Inhibitors []Inhibitor `yaml:"inhibitors"`
 	}
-	if err := unmarshalYamlFile(filepath.Join(root, "global", "inhibitors.yaml"), &ihWrapped, true); err != nil {
+	ihFile := filepath.Join(root, "global", "inhibitors.yaml")
+	if err := unmarshalYamlFile(ihFile, &ihWrapped, true); err != nil {
 		return err
 	}
+	stampFile(ihWrapped.Inhibitors, ihFile)
 	p.Inhibitors = append(p.Inhibitors, ihWrapped.Inhibitors...)
 
 	// global/root_route.yaml
 	var routeWrapped struct {
-		Route am.Route `yaml:"route"`
+		Route rootRoute `yaml:"route"`
 	}
-	if err := unmarshalYamlFile(filepath.Join(root, "global", "root_route.yaml"), &routeWrapped, true); err != nil {
+	routeFile := filepath.Join(root, "global", "root_route.yaml")
+	if err := unmarshalYamlFile(routeFile, &routeWrapped, true); err != nil {
 		return err
 	}
-	p.RootRoute = routeWrapped.Route
+	routeWrapped.Route.setFile(routeFile)
+	p.RootRoute = routeWrapped.Route.Route
+	p.RootRouteFile = routeWrapped.Route.Source
 
 	return nil
 }
 
 func loadTeam(teamPath string, t *Team) error {
+	// team.yaml (optional)
+	var tc TeamConfig
+	tcFile := filepath.Join(teamPath, "team.yaml")
+	if err := unmarshalYamlFile(tcFile, &tc, true); err != nil {
+		return err
+	}
+	tc.setFile(tcFile)
+	t.Ownership = tc.Ownership
+	t.DefaultNotify = tc.DefaultNotify
+	t.TeamFile = tc.Source
+
 	// channels.yaml
 	var chWrapped struct {
 		Channels []Channel `yaml:"channels"`
 	}
-	if err := unmarshalYamlFile(filepath.Join(teamPath, "channels.yaml"), &chWrapped, false); err != nil {
+	chFile := filepath.Join(teamPath, "channels.yaml")
+	if err := unmarshalYamlFile(chFile, &chWrapped, false); err != nil {
 		return err
 	}
+	stampFile(chWrapped.Channels, chFile)
 	t.Channels = append(t.Channels, chWrapped.Channels...)
 
 	// flows.yaml
 	var fWrapped struct {
 		Flows []Flow `yaml:"flows"`
 	}
-	if err := unmarshalYamlFile(filepath.Join(teamPath, "flows.yaml"), &fWrapped, false); err != nil {
+	fFile := filepath.Join(teamPath, "flows.yaml")
+	if err := unmarshalYamlFile(fFile, &fWrapped, false); err != nil {
 		return err
 	}
+	stampFile(fWrapped.Flows, fFile)
 	t.Flows = append(t.Flows, fWrapped.Flows...)
 
 	// silence_windows.yaml
 	var swWrapped struct {
 		SilenceWindows []SilenceWindow `yaml:"silence_windows"`
 	}
-	if err := unmarshalYamlFile(filepath.Join(teamPath, "silence_windows.yaml"), &swWrapped, false); err != nil {
+	swFile := filepath.Join(teamPath, "silence_windows.yaml")
+	if err := unmarshalYamlFile(swFile, &swWrapped, false); err != nil {
 		return err
 	}
+	stampFile(swWrapped.SilenceWindows, swFile)
 	t.SilenceWindows = append(t.SilenceWindows, swWrapped.SilenceWindows...)
 
-	// // inhibitors.yaml (optional)
-	// b, err = os.ReadFile(filepath.Join(teamPath, "inhibitors.yaml"))
-	// if err != nil {
-	// 	if os.IsNotExist(err) {
-	// 		return nil
-	// 	}
-	// 	return fmt.Errorf("failed to read %s: %w", filepath.Join(teamPath, "inhibitors.yaml"), err)
-	// }
+	// inhibitors.yaml (optional)
+	var ihWrapped struct {
+		Inhibitors []Inhibitor `yaml:"inhibitors"`
+	}
+	ihFile := filepath.Join(teamPath, "inhibitors.yaml")
+	if err := unmarshalYamlFile(ihFile, &ihWrapped, true); err != nil {
+		return err
+	}
+	stampFile(ihWrapped.Inhibitors, ihFile)
+	t.Inhibitors = append(t.Inhibitors, ihWrapped.Inhibitors...)
 
-	// var ihwrapped struct {
-	// 	Inhibitors []Inhibitor `yaml:"inhibitors"`
-	// }
+	// tests.yaml (optional)
+	var tWrapped struct {
+		Tests []RouteTest `yaml:"tests"`
+	}
+	tFile := filepath.Join(teamPath, "tests.yaml")
+	if err := unmarshalYamlFile(tFile, &tWrapped, true); err != nil {
+		return err
+	}
+	stampFile(tWrapped.Tests, tFile)
+	t.Tests = append(t.Tests, tWrapped.Tests...)
 
-	// if err := yaml.Unmarshal(b, &ihwrapped); err != nil {
-	// 	return fmt.Errorf("failed to parse %s: %w", filepath.Join(teamPath, "inhibitors.yaml"), err)
-	// }
+	// templates/*.tmpl (optional)
+	files, err := filepath.Glob(filepath.Join(teamPath, "templates", "*.tmpl"))
+	if err != nil {
+		return fmt.Errorf("failed to list templates: %w", err)
+	}
+	sort.Strings(files)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", f, err)
+		}
+		t.Templates = append(t.Templates, Template{
+			Name:    filepath.Base(f),
+			Path:    f,
+			Content: string(b),
+		})
+	}
 
-	// t.Inhibitors = append(t.Inhibitors, ihwrapped.Inhibitors...)
+	// alerts/*.yaml (optional, Prometheus rule files)
+	rules, err := loadAlertRules(filepath.Join(teamPath, "alerts"))
+	if err != nil {
+		return err
+	}
+	t.Alerts = append(t.Alerts, rules...)
 
 	return nil
-}
\ No newline at end of file
+}
+
+// loadAlertRules reads the alerting rules of every rule file in dir.
+func loadAlertRules(dir string) ([]AlertRule, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		m, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("failed to list alert rules: %w", err)
+		}
+		files = append(files, m...)
+	}
+	sort.Strings(files)
+
+	var out []AlertRule
+	for _, f := range files {
+		var rf struct {
+			Groups []struct {
+				Rules []AlertRule `yaml:"rules"`
+			} `yaml:"groups"`
+		}
+		if err := unmarshalYamlFile(f, &rf, false); err != nil {
+			return nil, err
+		}
+		for _, g := range rf.Groups {
+			for _, r := range g.Rules {
+				if r.Name == "" {
+					continue
+				}
+				r.setFile(f)
+				out = append(out, r)
+			}
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,132 @@
+package dsl
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nyambati/fuse/internal/am"
+)
+
+// Matchers is a list of label matchers (a flow's `when` block). In YAML it
+// accepts any of these forms, which all normalise to the same slice:
+//
+//	when: {severity: critical, team: payments}     # map form: equality
+//	when: {env: "!=staging", service: "=~api|web"} # map form with operator prefix
+//	when:
+//	  - 'severity=~"crit|page"'                    # Alertmanager matcher strings
+//	  - team: payments                             # single-entry maps
+//	  - {label: env, op: "!=", value: staging}     # explicit struct form
+type Matchers []Matcher
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (ms *Matchers) UnmarshalYAML(node *yaml.Node) error {
+	var out Matchers
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			m, err := matcherFromPair(node.Content[i], node.Content[i+1])
+			if err != nil {
+				return err
+			}
+			out = append(out, m)
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			var m Matcher
+			if err := item.Decode(&m); err != nil {
+				return err
+			}
+			out = append(out, m)
+		}
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			break
+		}
+		var m Matcher
+		if err := node.Decode(&m); err != nil {
+			return err
+		}
+		out = append(out, m)
+	default:
+		return fmt.Errorf("line %d: matchers must be a map, a list or a matcher string", node.Line)
+	}
+
+	*ms = out
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler for a single list item: an
+// Alertmanager matcher string, a single `label: value` pair, or the explicit
+// {label, op, value} struct.
+func (m *Matcher) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		pm, err := am.ParseMatcher(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*m = Matcher{Label: pm.Name, Op: pm.Op, Value: pm.Value}
+		return nil
+
+	case yaml.MappingNode:
+		if isExplicitMatcher(node) {
+			type plain Matcher // avoid recursing into this method
+			var p plain
+			if err := node.Decode(&p); err != nil {
+				return err
+			}
+			*m = Matcher(p)
+			return nil
+		}
+		if len(node.Content) != 2 {
+			return fmt.Errorf("line %d: matcher list items must have a single `label: value` pair", node.Line)
+		}
+		pm, err := matcherFromPair(node.Content[0], node.Content[1])
+		if err != nil {
+			return err
+		}
+		*m = pm
+		return nil
+	}
+
+	return fmt.Errorf("line %d: invalid matcher", node.Line)
+}
+
+// isExplicitMatcher reports whether a mapping uses the {label, op, value} form.
+func isExplicitMatcher(node *yaml.Node) bool {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "label" {
+			return true
+		}
+	}
+	return false
+}
+
+// matcherFromPair converts `label: value` where value may start with an
+// operator (!=, =~, !~ or =). A bare value means equality.
+func matcherFromPair(key, val *yaml.Node) (Matcher, error) {
+	if val.Kind != yaml.ScalarNode {
+		return Matcher{}, fmt.Errorf("line %d: value for matcher %q must be a string", val.Line, key.Value)
+	}
+
+	label := strings.TrimSpace(key.Value)
+	value := val.Value
+	op := am.MatchEqual
+
+	// Only unquoted or explicitly quoted strings carry operators; numbers
+	// and booleans are literal values.
+	if val.Tag == "!!str" {
+		for _, prefix := range []string{am.MatchRegexp, am.MatchNotRegexp, am.MatchNotEqual, am.MatchEqual} {
+			if strings.HasPrefix(value, prefix) {
+				op = prefix
+				value = value[len(prefix):]
+				break
+			}
+		}
+	}
+
+	return Matcher{Label: label, Op: op, Value: value}, nil
+}
@@ -0,0 +1,121 @@
+package dsl_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMatchersUnmarshal(t *testing.T) {
+	want := dsl.Matchers{
+		{Label: "severity", Op: "=~", Value: "crit|page"},
+		{Label: "team", Op: "=", Value: "payments"},
+		{Label: "env", Op: "!=", Value: "staging"},
+		{Label: "service", Op: "!~", Value: "batch-.*"},
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		want dsl.Matchers
+	}{
+		{
+			name: "map form with operator prefixes",
+			in: `
+when:
+  severity: "=~crit|page"
+  team: payments
+  env: "!=staging"
+  service: "!~batch-.*"
+`,
+			want: want,
+		},
+		{
+			name: "alertmanager matcher strings",
+			in: `
+when:
+  - 'severity=~"crit|page"'
+  - team="payments"
+  - env != staging
+  - 'service!~"batch-.*"'
+`,
+			want: want,
+		},
+		{
+			name: "explicit struct form",
+			in: `
+when:
+  - {label: severity, op: "=~", value: "crit|page"}
+  - {label: team, op: "=", value: payments}
+  - {label: env, op: "!=", value: staging}
+  - {label: service, op: "!~", value: "batch-.*"}
+`,
+			want: want,
+		},
+		{
+			name: "mixed list items",
+			in: `
+when:
+  - severity: "=~crit|page"
+  - team: payments
+  - {label: env, op: "!=", value: staging}
+  - 'service!~"batch-.*"'
+`,
+			want: want,
+		},
+		{
+			name: "template generated map",
+			in:   "when: {severity: critical, team: payments}",
+			want: dsl.Matchers{
+				{Label: "severity", Op: "=", Value: "critical"},
+				{Label: "team", Op: "=", Value: "payments"},
+			},
+		},
+		{
+			name: "non-string values are literal",
+			in:   "when: {code: 500, paging: true}",
+			want: dsl.Matchers{
+				{Label: "code", Op: "=", Value: "500"},
+				{Label: "paging", Op: "=", Value: "true"},
+			},
+		},
+		{
+			name: "single matcher string",
+			in:   `when: 'severity="critical"'`,
+			want: dsl.Matchers{{Label: "severity", Op: "=", Value: "critical"}},
+		},
+		{
+			name: "empty",
+			in:   "when:",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out struct {
+				When dsl.Matchers `yaml:"when"`
+			}
+			require.NoError(t, yaml.Unmarshal([]byte(tt.in), &out))
+			assert.Equal(t, tt.want, out.When)
+		})
+	}
+}
+
+func TestMatchersUnmarshal_Errors(t *testing.T) {
+	tests := []string{
+		"when:\n  - 'severity'",
+		"when:\n  - {severity: critical, team: payments}",
+		"when:\n  severity: [critical, page]",
+	}
+
+	for _, in := range tests {
+		var out struct {
+			When dsl.Matchers `yaml:"when"`
+		}
+		assert.Error(t, yaml.Unmarshal([]byte(in), &out), in)
+	}
+}
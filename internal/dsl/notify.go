@@ -0,0 +1,39 @@
+package dsl
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Targets lists the channels a flow notifies. In YAML it accepts a single
+// channel name or a list of names:
+//
+//	notify: slack
+//	notify: [slack, pager]
+type Targets []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *Targets) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" || node.Value == "" {
+			*t = nil
+			return nil
+		}
+		*t = Targets{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var out Targets
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: notify targets must be channel names", item.Line)
+			}
+			out = append(out, item.Value)
+		}
+		*t = out
+		return nil
+	default:
+		return fmt.Errorf("line %d: notify must be a channel name or a list of channel names", node.Line)
+	}
+}
@@ -0,0 +1,77 @@
+package dsl
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// DefaultReceiverPattern namespaces receivers by team so that channels with
+// the same name in different teams do not collide.
+const DefaultReceiverPattern = "{team}/{channel}"
+
+// ReceiverName returns the Alertmanager receiver name of a team channel.
+// pattern may use {team} and {channel}; an empty pattern means
+// DefaultReceiverPattern.
+func ReceiverName(pattern, team, channel string) string {
+	if pattern == "" {
+		pattern = DefaultReceiverPattern
+	}
+	return strings.NewReplacer("{team}", team, "{channel}", channel).Replace(pattern)
+}
+
+// GlobalScope names the project-wide scope: global channels are owned by a
+// pseudo-team of this name and referenced from teams as "global:<channel>".
+const GlobalScope = "global"
+
+const globalPrefix = GlobalScope + ":"
+
+// ParseTarget splits a notify target into the channel name and whether it
+// refers to a global channel.
+func ParseTarget(target string) (channel string, global bool) {
+	if strings.HasPrefix(target, globalPrefix) {
+		return strings.TrimPrefix(target, globalPrefix), true
+	}
+	return target, false
+}
+
+// TargetReceiverName returns the receiver name for a notify target of team.
+func TargetReceiverName(pattern, team, target string) string {
+	if ch, global := ParseTarget(target); global {
+		return ReceiverName(pattern, GlobalScope, ch)
+	}
+	return ReceiverName(pattern, team, target)
+}
+
+// GlobalTeam returns the global channels wrapped in a pseudo-team, so that
+// code handling team channels can process them the same way.
+func (p Project) GlobalTeam() Team {
+	return Team{
+		Name:     GlobalScope,
+		Path:     filepath.Join(p.Root, GlobalScope),
+		Channels: p.Channels,
+	}
+}
+
+// WindowName returns the Alertmanager time interval name of a silence window
+// owned by scope (a team name or GlobalScope). Windows are namespaced with
+// the receiver pattern, so teams can reuse window names without colliding.
+func WindowName(pattern, scope, window string) string {
+	return ReceiverName(pattern, scope, window)
+}
+
+// LookupWindow resolves a silence_when reference of team: the team's own
+// window wins over a global one of the same name. scope is the owner of the
+// window found.
+func (p Project) LookupWindow(team Team, name string) (sw SilenceWindow, scope string, ok bool) {
+	for _, w := range team.SilenceWindows {
+		if w.Name == name {
+			return w, team.Name, true
+		}
+	}
+	for _, w := range p.SilenceWindows {
+		if w.Name == name {
+			return w, GlobalScope, true
+		}
+	}
+	return SilenceWindow{}, "", false
+}
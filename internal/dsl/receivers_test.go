@@ -0,0 +1,23 @@
+package dsl_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiverName(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{pattern: "", want: "payments/slack"},
+		{pattern: "{channel}", want: "slack"},
+		{pattern: "am-{team}-{channel}", want: "am-payments-slack"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, dsl.ReceiverName(tt.pattern, "payments", "slack"), tt.pattern)
+	}
+}
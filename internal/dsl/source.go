@@ -0,0 +1,234 @@
+package dsl
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/diag"
+)
+
+// Pos is a position in a DSL source file. Line and Column are 1-based; a
+// zero Pos means the position is unknown, e.g. for elements built in code.
+type Pos struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position is known.
+func (p Pos) IsValid() bool {
+	return p.Line > 0
+}
+
+// Locate points d at p. When p is unknown d is returned unchanged, so the
+// File the caller already set serves as a fallback.
+func (p Pos) Locate(d diag.Diagnostic) diag.Diagnostic {
+	if !p.IsValid() {
+		return d
+	}
+	if p.File != "" {
+		d.File = p.File
+	}
+	d.Line = p.Line
+	d.Column = p.Column
+	return d
+}
+
+// Source records where a DSL element and each of its keys were declared.
+// Elements embed it and fill it in while being decoded.
+type Source struct {
+	Pos  Pos
+	keys map[string]Pos
+}
+
+// KeyPos returns the position of key in the element, or the element's own
+// position when the key was not written.
+func (s Source) KeyPos(key string) Pos {
+	if p, ok := s.keys[key]; ok {
+		return p
+	}
+	return s.Pos
+}
+
+// record captures the positions of node and, for mappings, of its keys.
+func (s *Source) record(node *yaml.Node) {
+	s.Pos = nodePos(node)
+	s.keys = keyPositions(node)
+}
+
+// setFile stamps file on the recorded positions; decoding alone only knows
+// lines and columns.
+func (s *Source) setFile(file string) {
+	s.Pos.File = file
+	for k, p := range s.keys {
+		p.File = file
+		s.keys[k] = p
+	}
+}
+
+func nodePos(node *yaml.Node) Pos {
+	return Pos{Line: node.Line, Column: node.Column}
+}
+
+// keyPositions maps each key of a mapping node to its position.
+func keyPositions(node *yaml.Node) map[string]Pos {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	out := make(map[string]Pos, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		out[node.Content[i].Value] = nodePos(node.Content[i])
+	}
+	return out
+}
+
+// mappingValue returns the value node of key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+type fileSetter interface {
+	setFile(file string)
+}
+
+// stampFile sets the source file of every element loaded from it.
+func stampFile[T any, P interface {
+	*T
+	fileSetter
+}](items []T, file string) {
+	for i := range items {
+		P(&items[i]).setFile(file)
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (c *Channel) UnmarshalYAML(node *yaml.Node) error {
+	type plain Channel // avoid recursing into this method
+	if err := node.Decode((*plain)(c)); err != nil {
+		return err
+	}
+	c.record(node)
+	c.configKeys = nil
+	if configs := mappingValue(node, "configs"); configs != nil && configs.Kind == yaml.SequenceNode {
+		for _, item := range configs.Content {
+			keys := keyPositions(item)
+			if keys == nil {
+				keys = map[string]Pos{}
+			}
+			keys[""] = nodePos(item)
+			c.configKeys = append(c.configKeys, keys)
+		}
+	}
+	return nil
+}
+
+// ConfigPos returns the position of key in configs[idx], falling back to the
+// config item and then to the channel. An empty key asks for the item.
+func (c Channel) ConfigPos(idx int, key string) Pos {
+	if idx >= 0 && idx < len(c.configKeys) {
+		if p, ok := c.configKeys[idx][key]; ok {
+			return p
+		}
+		return c.configKeys[idx][""]
+	}
+	return c.KeyPos("configs")
+}
+
+func (c *Channel) setFile(file string) {
+	c.Source.setFile(file)
+	for _, keys := range c.configKeys {
+		for k, p := range keys {
+			p.File = file
+			keys[k] = p
+		}
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (f *Flow) UnmarshalYAML(node *yaml.Node) error {
+	type plain Flow
+	if err := node.Decode((*plain)(f)); err != nil {
+		return err
+	}
+	f.record(node)
+	return nil
+}
+
+func (f *Flow) setFile(file string) {
+	f.Source.setFile(file)
+	stampFile(f.Flows, file)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (sw *SilenceWindow) UnmarshalYAML(node *yaml.Node) error {
+	type plain SilenceWindow
+	if err := node.Decode((*plain)(sw)); err != nil {
+		return err
+	}
+	sw.record(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (ih *Inhibitor) UnmarshalYAML(node *yaml.Node) error {
+	type plain Inhibitor
+	if err := node.Decode((*plain)(ih)); err != nil {
+		return err
+	}
+	ih.record(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (tc *TeamConfig) UnmarshalYAML(node *yaml.Node) error {
+	type plain TeamConfig
+	if err := node.Decode((*plain)(tc)); err != nil {
+		return err
+	}
+	tc.record(node)
+	return nil
+}
+
+// rootRoute decodes the root route of global/root_route.yaml, which is an
+// Alertmanager route as-is, recording source positions.
+type rootRoute struct {
+	am.Route
+	Source
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (r *rootRoute) UnmarshalYAML(node *yaml.Node) error {
+	if err := node.Decode(&r.Route); err != nil {
+		return err
+	}
+	r.record(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (r *AlertRule) UnmarshalYAML(node *yaml.Node) error {
+	type plain AlertRule
+	if err := node.Decode((*plain)(r)); err != nil {
+		return err
+	}
+	r.record(node)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler, recording source positions.
+func (rt *RouteTest) UnmarshalYAML(node *yaml.Node) error {
+	type plain RouteTest
+	if err := node.Decode((*plain)(rt)); err != nil {
+		return err
+	}
+	rt.record(node)
+	return nil
+}
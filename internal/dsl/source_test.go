@@ -0,0 +1,88 @@
+package dsl_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLoadProjectPositions(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "global", "global.yaml"), "global: {}\n")
+	writeFile(t, filepath.Join(root, "global", "root_route.yaml"), `route:
+  receiver: global:default
+  group_wait: 30s
+`)
+	team := filepath.Join(root, "teams", "payments")
+	writeFile(t, filepath.Join(team, "channels.yaml"), `channels:
+  - name: slack
+    type: slack
+    configs:
+      - channel: "#alerts"
+        api_url: ${SLACK}
+`)
+	writeFile(t, filepath.Join(team, "flows.yaml"), `flows:
+  - notify: slack
+    when:
+      severity: critical
+    flows:
+      - when: {env: prod}
+`)
+	writeFile(t, filepath.Join(team, "silence_windows.yaml"), "silence_windows: []\n")
+
+	proj, diags := dsl.LoadProject(root, nil)
+	require.Empty(t, diags)
+	require.Len(t, proj.Teams, 1)
+	payments := proj.Teams[0]
+
+	flows := filepath.Join(team, "flows.yaml")
+	f := payments.Flows[0]
+	assert.Equal(t, dsl.Pos{File: flows, Line: 2, Column: 5}, f.Pos)
+	assert.Equal(t, dsl.Pos{File: flows, Line: 3, Column: 5}, f.KeyPos("when"))
+	assert.Equal(t, f.Pos, f.KeyPos("group_by"), "unset keys fall back to the element")
+	assert.Equal(t, dsl.Pos{File: flows, Line: 6, Column: 9}, f.Flows[0].Pos)
+
+	channels := filepath.Join(team, "channels.yaml")
+	ch := payments.Channels[0]
+	assert.Equal(t, dsl.Pos{File: channels, Line: 2, Column: 5}, ch.Pos)
+	assert.Equal(t, dsl.Pos{File: channels, Line: 6, Column: 9}, ch.ConfigPos(0, "api_url"))
+	assert.Equal(t, dsl.Pos{File: channels, Line: 5, Column: 9}, ch.ConfigPos(0, "missing"))
+
+	rootRoute := filepath.Join(root, "global", "root_route.yaml")
+	assert.Equal(t, "global:default", proj.RootRoute.Receiver)
+	assert.Equal(t, dsl.Pos{File: rootRoute, Line: 3, Column: 3}, proj.RootRouteFile.KeyPos("group_wait"))
+}
+
+func TestLoadProjectParseErrorPosition(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "global", "global.yaml"), "global: {}\n")
+	team := filepath.Join(root, "teams", "payments")
+	writeFile(t, filepath.Join(team, "channels.yaml"), "channels: []\n")
+	writeFile(t, filepath.Join(team, "flows.yaml"), "flows:\n  - when:\n      severity: [critical\n")
+
+	_, diags := dsl.LoadProject(root, nil)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "READ_TEAM", diags[0].Code)
+	assert.Equal(t, filepath.Join(team, "flows.yaml"), diags[0].File)
+	assert.Positive(t, diags[0].Line)
+}
+
+func TestPosLocate(t *testing.T) {
+	d := diag.Diagnostic{Code: "X", File: "teams/payments"}
+
+	assert.Equal(t, d, dsl.Pos{}.Locate(d), "unknown positions keep the fallback file")
+	assert.Equal(t,
+		diag.Diagnostic{Code: "X", File: "teams/payments/flows.yaml", Line: 3, Column: 7},
+		dsl.Pos{File: "teams/payments/flows.yaml", Line: 3, Column: 7}.Locate(d))
+}
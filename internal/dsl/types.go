@@ -13,9 +13,13 @@ type Global map[string]any
 
 // Project is the in-memory representation of a Fuse project DSL.
 type Project struct {
-	Root           string
-	Global         Global
-	RootRoute      am.Route
+	Root      string
+	Global    Global
+	RootRoute am.Route
+	// RootRouteFile records where global/root_route.yaml declared the root
+	// route's keys.
+	RootRouteFile  Source
+	Channels       []Channel // shared channels from global/channels.yaml
 	SilenceWindows []SilenceWindow
 	Inhibitors     []Inhibitor
 	Teams          []Team
@@ -23,15 +27,74 @@ type Project struct {
 
 // Team collects a team's DSL files.
 type Team struct {
-	Name           string
-	Path           string
+	Name string
+	Path string
+	// Ownership selects the team's alerts (see OwnershipMatchers).
+	Ownership Matchers
+	// DefaultNotify receives team alerts that no flow matches.
+	DefaultNotify  Targets
 	Channels       []Channel
 	Flows          []Flow
 	SilenceWindows []SilenceWindow
 	Inhibitors     []Inhibitor
-	// Future: Alerts, Templates references, etc.
+	Templates      []Template
+	Alerts         []AlertRule
+	Tests          []RouteTest
+	// TeamFile records where team.yaml declared its keys.
+	TeamFile Source
 }
 
+// Template is a notification template file from teams/<name>/templates.
+type Template struct {
+	Name    string // file name, e.g. slack.tmpl
+	Path    string
+	Content string
+}
+
+// AlertRule is a Prometheus alerting rule from teams/<name>/alerts/*.yaml.
+// Recording rules are skipped when loading.
+type AlertRule struct {
+	Name        string            `yaml:"alert"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for"`
+	Labels      map[string]string `yaml:"labels"`
+	Annotations map[string]string `yaml:"annotations"`
+	Source      `yaml:"-"`
+}
+
+// RouteTest is a routing expectation from teams/<name>/tests.yaml: an alert
+// with Labels, evaluated at At (RFC 3339; empty means now), should notify
+// exactly the Expect receivers. Expect entries are receiver names or, like a
+// flow's notify, the team's channel names and global:<name>. An empty Expect
+// asserts that nothing is notified, e.g. because a silence window is active.
+type RouteTest struct {
+	Name   string            `yaml:"name"`
+	Labels map[string]string `yaml:"labels"`
+	At     string            `yaml:"at"`
+	Expect Targets           `yaml:"expect"`
+	Source `yaml:"-"`
+}
+
+// OwnershipMatchers returns the matchers that select the team's alerts: the
+// ownership block of team.yaml, or team="<name>" when none is declared. The
+// team's flows nest under a parent route with these matchers.
+func (t Team) OwnershipMatchers() Matchers {
+	if len(t.Ownership) > 0 {
+		return t.Ownership
+	}
+	return Matchers{{Label: TeamLabel, Op: "=", Value: t.Name}}
+}
+
+// TeamConfig is the optional teams/<name>/team.yaml file.
+type TeamConfig struct {
+	Ownership     Matchers `yaml:"ownership"`
+	DefaultNotify Targets  `yaml:"default_notify"`
+	Source        `yaml:"-"`
+}
+
+// TeamLabel is the label of the default ownership matcher.
+const TeamLabel = "team"
+
 // SilenceWindow defines a named recurring mute period.
 type SilenceWindow struct {
 	Name        string   `yaml:"name"`
@@ -42,6 +105,7 @@ type SilenceWindow struct {
 	Months      []string `yaml:"months"`
 	Years       []string `yaml:"years"`
 	Timezone    string   `yaml:"timezone"`
+	Source      `yaml:"-"`
 }
 
 // Channel represents a notification destination.
@@ -49,30 +113,39 @@ type Channel struct {
 	Name    string           `yaml:"name"`
 	Type    string           `yaml:"type"`
 	Configs []map[string]any `yaml:"configs,omitempty"`
+	Source  `yaml:"-"`
+
+	configKeys []map[string]Pos // key positions per config; "" is the item
 }
 
+// Matcher is a single label condition. See Matchers for the accepted YAML forms.
 type Matcher struct {
 	Label string `yaml:"label"`
 	Op    string `yaml:"op"`
 	Value string `yaml:"value"`
 }
 
-// Flow is a single routing rule inside flows.yaml.
+// Flow is a single routing rule inside flows.yaml. Sub-flows in Flows are
+// tried in order for alerts the flow matches; they inherit notify, grouping,
+// timing and silence_when unless they set their own (silence_when: [] clears it).
 type Flow struct {
-	Notify        string    // normalized: always a slice (string in YAML expands to 1 item)
-	When          []Matcher `yaml:"when"`
-	GroupBy       []string  `yaml:"group_by,omitempty"`
-	WaitFor       string    `yaml:"wait_for,omitempty"`
-	GroupInterval string    `yaml:"group_interval,omitempty"`
-	RepeatAfter   string    `yaml:"repeat_after,omitempty"`
-	SilenceWhen   []string  `yaml:"silence_when,omitempty"`
-	Continue      *bool     `yaml:"continue,omitempty"`
+	Notify        Targets  `yaml:"notify"`
+	When          Matchers `yaml:"when"`
+	GroupBy       []string `yaml:"group_by,omitempty"`
+	WaitFor       string   `yaml:"wait_for,omitempty"`
+	GroupInterval string   `yaml:"group_interval,omitempty"`
+	RepeatAfter   string   `yaml:"repeat_after,omitempty"`
+	SilenceWhen   []string `yaml:"silence_when,omitempty"`
+	Continue      *bool    `yaml:"continue,omitempty"`
+	Flows         []Flow   `yaml:"flows,omitempty"`
+	Source        `yaml:"-"`
 }
 
 // Inhibitor represents a simplified inhibit rule.
 type Inhibitor struct {
-	Name     string            `yaml:"name"`
-	If       map[string]string `yaml:"if"`
-	Suppress map[string]string `yaml:"suppress"`
-	When     []string          `yaml:"when"`
+	Name     string   `yaml:"name"`
+	If       Matchers `yaml:"if"`
+	Suppress Matchers `yaml:"suppress"`
+	When     []string `yaml:"when"`
+	Source   `yaml:"-"`
 }
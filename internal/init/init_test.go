@@ -5,7 +5,12 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
 	initer "github.com/nyambati/fuse/internal/init"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/nyambati/fuse/internal/validate"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,7 +31,7 @@ func TestInitProject(t *testing.T) {
 				files []string
 				dirs  []string
 			}{
-				files: []string{".fuse.yaml", "global/global.yaml", "global/silence_windows.yaml", "teams/README.md"},
+				files: []string{".fuse.yaml", "global/global.yaml", "global/channels.yaml", "global/root_route.yaml", "global/silence_windows.yaml", "teams/README.md"},
 				dirs:  []string{"dist"},
 			},
 		},
@@ -114,9 +119,12 @@ func TestInitTeam(t *testing.T) {
 				dirs  []string
 			}{
 				files: []string{
+					"teams/myteam/team.yaml",
 					"teams/myteam/channels.yaml",
 					"teams/myteam/flows.yaml",
 					"teams/myteam/silence_windows.yaml",
+					"teams/myteam/inhibitors.yaml",
+					"teams/myteam/tests.yaml",
 					"teams/myteam/alerts/example.yaml",
 					"teams/myteam/templates/README.md",
 				},
@@ -209,3 +217,32 @@ func TestInitTeam(t *testing.T) {
 		})
 	}
 }
+
+func TestInitScaffoldValidates(t *testing.T) {
+	tests := []struct {
+		name  string
+		teams []string
+	}{
+		{name: "TestInitScaffoldValidates_ProjectOnly"},
+		{name: "TestInitScaffoldValidates_OneTeam", teams: []string{"payments"}},
+		{name: "TestInitScaffoldValidates_TwoTeams", teams: []string{"payments", "search"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, initer.InitProject(initer.InitOptions{Path: dir, Quiet: true}))
+			for _, team := range tt.teams {
+				require.NoError(t, initer.InitTeam(initer.InitOptions{Path: dir, Team: team, Quiet: true}))
+			}
+
+			proj, loadDiags := dsl.LoadProject(dir, nil)
+			amc, parseDiags := parse.ToAlertmanager(proj, &secrets.EnvProvider{}, parse.Options{})
+			valDiags := validate.Project(proj, amc, validate.Options{})
+
+			for _, d := range validate.Merge(loadDiags, parseDiags, valDiags) {
+				assert.NotEqual(t, diag.LevelError, d.Level, "%s: %s", d.Code, d.Message)
+			}
+		})
+	}
+}
@@ -25,6 +25,8 @@ func InitProject(options InitOptions) error {
 	files := []string{
 		"project/.fuse.yaml",
 		"project/global/global.yaml",
+		"project/global/channels.yaml",
+		"project/global/root_route.yaml",
 		"project/global/silence_windows.yaml",
 		"project/teams/README.md",
 	}
@@ -27,9 +27,12 @@ func InitTeam(options InitOptions) error {
 	}
 
 	files := []string{
+		"team/team.yaml",
 		"team/channels.yaml",
 		"team/flows.yaml",
 		"team/silence_windows.yaml",
+		"team/inhibitors.yaml",
+		"team/tests.yaml",
 		"team/alerts/example.yaml",
 		"team/templates/README.md",
 	}
@@ -37,7 +40,8 @@ func InitTeam(options InitOptions) error {
 	for _, file := range files {
 		target := filepath.Join(teamDir, trimTemplatePrefix(file))
 		if options.NoSample {
-			if file == "team/channels.yaml" || file == "team/flows.yaml" {
+			switch file {
+			case "team/team.yaml", "team/channels.yaml", "team/flows.yaml", "team/inhibitors.yaml", "team/tests.yaml":
 				continue
 			}
 		}
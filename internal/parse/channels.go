@@ -1,26 +1,32 @@
 package parse
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/channels"
 	"github.com/nyambati/fuse/internal/diag"
 	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/secrets"
 )
 
-// BuildReceivers maps team channels into AM receivers.
+// BuildReceivers maps global and team channels into AM receivers. Global
+// channels are emitted once, however many teams reference them.
 // Does not deduplicate — that’s handled later in validation.
-// Channel.Type determines which AM config array gets populated.
-func BuildReceivers(proj dsl.Project) ([]am.Receiver, []diag.Diagnostic) {
+// Channel.Type selects the channels registry entry that renders the configs.
+// ${VAR} placeholders in channel configs are resolved through prov.
+func BuildReceivers(proj dsl.Project, prov secrets.Provider, opts Options) ([]am.Receiver, []diag.Diagnostic) {
 	var (
 		receivers   []am.Receiver
 		diagnostics []diag.Diagnostic
 	)
 
-	for _, team := range proj.Teams {
+	owners := append([]dsl.Team{proj.GlobalTeam()}, proj.Teams...)
+	for _, team := range owners {
 		for idx, channel := range team.Channels {
-			receiver, diags := buildReceiver(team, idx, channel)
+			receiver, diags := buildReceiver(team, idx, channel, prov, opts)
 			if receiver != nil {
 				receivers = append(receivers, *receiver)
 			}
@@ -32,46 +38,148 @@ func BuildReceivers(proj dsl.Project) ([]am.Receiver, []diag.Diagnostic) {
 }
 
 // buildReceiver processes a single channel and returns a receiver and any diagnostics.
-func buildReceiver(team dsl.Team, idx int, channel dsl.Channel) (*am.Receiver, []diag.Diagnostic) {
+func buildReceiver(team dsl.Team, idx int, channel dsl.Channel, prov secrets.Provider, opts Options) (*am.Receiver, []diag.Diagnostic) {
 	var diags []diag.Diagnostic
 
 	// Validate and normalize the channel name
 	name := strings.TrimSpace(channel.Name)
 	if name == "" {
-		diags = append(diags, diag.Diagnostic{
+		diags = append(diags, channel.Pos.Locate(diag.Diagnostic{
 			Level:   diag.LevelError,
 			Code:    "CHAN_NAME_EMPTY",
 			Message: fmt.Sprintf("team %q channel[%d] has empty name", team.Name, idx),
 			File:    team.Path,
-		})
+		}))
 		return nil, diags
 	}
 
-	receiver := am.Receiver{Name: name}
+	receiver := am.Receiver{Name: dsl.ReceiverName(opts.ReceiverPattern, team.Name, name)}
 
 	if len(channel.Configs) < 1 {
-		diags = append(diags, diag.Diagnostic{
+		diags = append(diags, channel.Pos.Locate(diag.Diagnostic{
 			Level:   diag.LevelWarn,
 			Code:    "CHAN_CONFIGS_EMPTY",
 			Message: fmt.Sprintf("%s channel %q in team %q has no configs", channel.Type, name, team.Name),
-		})
+		}))
 		return nil, diags
 	}
 
-	// Process channel configuration based on its type
-	channelType := strings.ToLower(strings.TrimSpace(channel.Type))
-	switch channelType {
-	case "slack":
-		receiver.SlackConfigs = channel.Configs
-	case "opsgenie":
-		receiver.OpsgenieConfigs = channel.Configs
-	default:
-		diags = append(diags, diag.Diagnostic{
+	configs, sDiags := resolveChannelSecrets(team, channel, prov, opts)
+	diags = append(diags, sDiags...)
+
+	// Render the configs under the key the channel type declares
+	t, ok := channels.Lookup(channel.Type)
+	if !ok {
+		diags = append(diags, channel.KeyPos("type").Locate(diag.Diagnostic{
 			Level:   diag.LevelError,
 			Code:    "CHAN_TYPE_UNKNOWN",
 			Message: fmt.Sprintf("unknown channel type %q for channel %q in team %q", channel.Type, name, team.Name),
-		})
+		}))
+		return &receiver, diags
+	}
+	if err := t.Build(&receiver, configs); err != nil {
+		diags = append(diags, buildErrorDiags(team, channel, err)...)
 	}
 
 	return &receiver, diags
 }
+
+// buildErrorDiags reports the error of channels.Type.Build. A field that does
+// not convert to its type is reported here only when a placeholder produced
+// the value; literal values of the wrong type are reported by validation.
+func buildErrorDiags(team dsl.Team, channel dsl.Channel, err error) []diag.Diagnostic {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var diags []diag.Diagnostic
+	for _, e := range errs {
+		var fe *channels.FieldError
+		if !errors.As(e, &fe) {
+			diags = append(diags, channel.Pos.Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "CHAN_BUILD_FAILED",
+				Message: fmt.Sprintf("channel %q in team %q: %v", channel.Name, team.Name, e),
+			}))
+			continue
+		}
+		if raw, _ := channel.Configs[fe.Config][fe.Field].(string); !secrets.HasPlaceholders(raw) {
+			continue
+		}
+		diags = append(diags, channel.ConfigPos(fe.Config, fe.Field).Locate(diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "CHAN_FIELD_TYPE",
+			Message: fmt.Sprintf("channel %q in team %q configs[%d].%s: resolved value %v", channel.Name, team.Name, fe.Config, fe.Field, fe.Err),
+			File:    team.Path,
+		}))
+	}
+	return diags
+}
+
+// resolveChannelSecrets returns a copy of the channel configs with ${VAR}
+// placeholders resolved. Unresolved keys are reported per field; they are
+// warnings unless opts.Strict is set.
+func resolveChannelSecrets(team dsl.Team, channel dsl.Channel, prov secrets.Provider, opts Options) ([]map[string]any, []diag.Diagnostic) {
+	var diags []diag.Diagnostic
+
+	level := diag.LevelWarn
+	if opts.Strict {
+		level = diag.LevelError
+	}
+
+	configs := make([]map[string]any, 0, len(channel.Configs))
+	for i, cfg := range channel.Configs {
+		field := fmt.Sprintf("configs[%d]", i)
+		resolved, unresolved, err := secrets.InterpolateValue(cfg, field, prov)
+		if err != nil {
+			diags = append(diags, channel.ConfigPos(i, "").Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "SECRET_PROVIDER_FAILED",
+				Message: fmt.Sprintf("secrets provider failed for channel %q in team %q (%s): %v", channel.Name, team.Name, field, err),
+				File:    team.Path,
+			}))
+		}
+		for _, u := range unresolved {
+			if u.Required {
+				// ${KEY:?message} is always fatal
+				msg := fmt.Sprintf("required secret ${%s} is not set in team %q channel %q field %s", u.Key, team.Name, channel.Name, u.Field)
+				if u.Message != "" {
+					msg += ": " + u.Message
+				}
+				diags = append(diags, configFieldPos(channel, i, u.Field).Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    "SECRET_REQUIRED",
+					Message: msg,
+					File:    team.Path,
+				}))
+				continue
+			}
+			diags = append(diags, configFieldPos(channel, i, u.Field).Locate(diag.Diagnostic{
+				Level:   level,
+				Code:    "SECRET_UNRESOLVED",
+				Message: fmt.Sprintf("unresolved secret ${%s} in team %q channel %q field %s", u.Key, team.Name, channel.Name, u.Field),
+				File:    team.Path,
+			}))
+		}
+
+		m, ok := resolved.(map[string]any)
+		if !ok {
+			m = cfg
+		}
+		configs = append(configs, m)
+	}
+
+	return configs, diags
+}
+
+// configFieldPos locates a field path such as configs[0].http_config.proxy_url
+// of configs[idx] by its top-level key.
+func configFieldPos(channel dsl.Channel, idx int, field string) dsl.Pos {
+	key := strings.TrimPrefix(field, fmt.Sprintf("configs[%d]", idx))
+	key = strings.TrimPrefix(key, ".")
+	if i := strings.IndexAny(key, ".["); i >= 0 {
+		key = key[:i]
+	}
+	return channel.ConfigPos(idx, key)
+}
@@ -0,0 +1,78 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapProvider resolves secrets from a map.
+type mapProvider map[string]string
+
+func (m mapProvider) Resolve(key string) (string, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return "", secrets.ErrNotFound
+}
+
+func TestBuildReceivers_ConvertsResolvedValues(t *testing.T) {
+	tests := []struct {
+		name      string
+		secrets   mapProvider
+		config    map[string]any
+		want      string
+		wantCodes []string
+	}{
+		{
+			name:    "placeholder resolves to a number",
+			secrets: mapProvider{"TG_CHAT": "-100123", "TG_TOKEN": "t"},
+			config:  map[string]any{"chat_id": "${TG_CHAT}", "bot_token": "${TG_TOKEN}"},
+			want:    "chat_id: -100123\n",
+		},
+		{
+			name:    "placeholder resolves to a bool",
+			secrets: mapProvider{"TG_CHAT": "1", "TG_TOKEN": "t", "RESOLVED": "true"},
+			config:  map[string]any{"chat_id": "${TG_CHAT}", "bot_token": "${TG_TOKEN}", "send_resolved": "${RESOLVED}"},
+			want:    "send_resolved: true\n",
+		},
+		{
+			name:      "placeholder resolves to something else",
+			secrets:   mapProvider{"TG_CHAT": "@ops", "TG_TOKEN": "t"},
+			config:    map[string]any{"chat_id": "${TG_CHAT}", "bot_token": "${TG_TOKEN}"},
+			wantCodes: []string{"CHAN_FIELD_TYPE"},
+		},
+		{
+			// Validation reports literal values of the wrong type.
+			name:    "literal string is not reported twice",
+			secrets: mapProvider{"TG_TOKEN": "t"},
+			config:  map[string]any{"chat_id": "@ops", "bot_token": "${TG_TOKEN}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj := dsl.Project{Teams: []dsl.Team{{
+				Name:     "payments",
+				Channels: []dsl.Channel{{Name: "tg", Type: "telegram", Configs: []map[string]any{tt.config}}},
+			}}}
+
+			recvs, diags := parse.BuildReceivers(proj, tt.secrets, parse.Options{})
+			var codes []string
+			for _, d := range diags {
+				codes = append(codes, d.Code)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+
+			require.Len(t, recvs, 1)
+			out, err := am.Marshal(am.Config{Receivers: recvs})
+			require.NoError(t, err)
+			assert.Contains(t, string(out), tt.want)
+		})
+	}
+}
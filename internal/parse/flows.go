@@ -8,39 +8,100 @@ import (
 	"github.com/nyambati/fuse/internal/dsl"
 )
 
-// BuildFlowRoutes converts all teams' flows into a flat slice of AM routes.
-// Caller typically wraps these under a single root route:
+// BuildFlowRoutes attaches one parent route per team under the root route.
+// The parent matches the team's ownership matchers, its flows nest beneath it,
+// and its default_notify receives whatever none of the flows match. Notify
+// targets and silence windows are rewritten to receiver and time interval
+// names with opts.ReceiverPattern, as are the names used by the routes written
+// in global/root_route.yaml.
+func BuildFlowRoutes(proj dsl.Project, opts Options) (am.Route, []diag.Diagnostic) {
+	root, diags := rewriteRootRoute(proj, opts)
 
-func BuildFlowRoutes(proj dsl.Project) (am.Route, []diag.Diagnostic) {
+	for _, team := range proj.Teams {
+		r, ok, d := buildTeamRoute(proj, team, opts)
+		if len(d) > 0 {
+			diags = append(diags, d...)
+		}
+		if ok {
+			root.Routes = append(root.Routes, r)
+		}
+	}
+
+	return root, diags
+}
+
+// buildTeamRoute renders a team's parent route. ok is false when the team has
+// neither flows nor a default receiver, so there is nothing to route.
+func buildTeamRoute(proj dsl.Project, team dsl.Team, opts Options) (am.Route, bool, []diag.Diagnostic) {
 	var diags []diag.Diagnostic
 
-	for _, team := range proj.Teams {
-		for idx, f := range team.Flows {
-			rs, d := mapFlowToRoutes(team, idx, f)
-			if len(d) > 0 {
-				diags = append(diags, d...)
-			}
-			proj.RootRoute.Routes = append(proj.RootRoute.Routes, rs...)
+	if len(team.Flows) == 0 && len(team.DefaultNotify) == 0 {
+		return am.Route{}, false, nil
+	}
+
+	matchers, mDiags := ToMatchers(team.OwnershipMatchers())
+	if len(mDiags) > 0 {
+		diags = append(diags, mDiags...)
+	}
+
+	defaults := receiverNames(team, team.DefaultNotify, opts)
+
+	source := "team/" + team.Name
+	r := am.Route{Matchers: matchers, Source: source}
+	if len(defaults) > 0 {
+		r.Receiver = defaults[0]
+	}
+
+	scope := flowScope{notify: defaults}
+	for idx, f := range team.Flows {
+		rs, d := mapFlowToRoutes(proj, team, fmt.Sprintf("flows[%d]", idx), f, scope, opts)
+		if len(d) > 0 {
+			diags = append(diags, d...)
 		}
+		r.Routes = append(r.Routes, rs...)
+	}
+
+	if len(defaults) > 1 {
+		r.Routes = append(r.Routes, fanOut(defaults, nil, source+" default_notify")...)
 	}
 
-	return proj.RootRoute, diags
+	return r, true, diags
+}
+
+// flowScope carries the settings a sub-flow inherits from its parent flow.
+// Grouping and timing are inherited by Alertmanager itself; notify targets and
+// silence windows are not, so they are passed down and rendered explicitly.
+// notify holds receiver names, not channel names; silenceWhen holds the
+// window names as written.
+type flowScope struct {
+	notify      []string
+	silenceWhen []string
 }
 
-func mapFlowToRoutes(team dsl.Team, idx int, f dsl.Flow) ([]am.Route, []diag.Diagnostic) {
+// mapFlowToRoutes renders a flow and its sub-flows; path names the flow in
+// diagnostics, e.g. "flows[0].flows[1]".
+func mapFlowToRoutes(proj dsl.Project, team dsl.Team, path string, f dsl.Flow, parent flowScope, opts Options) ([]am.Route, []diag.Diagnostic) {
 	var (
 		routes []am.Route
 		diags  []diag.Diagnostic
 	)
 
-	// ---- notify must exist ----
-	if f.Notify == "" {
-		diags = append(diags, diag.Diagnostic{
+	scope := parent
+	if len(f.Notify) > 0 {
+		scope.notify = receiverNames(team, f.Notify, opts)
+	}
+	if f.SilenceWhen != nil {
+		scope.silenceWhen = f.SilenceWhen
+	}
+
+	// ---- notify must exist (own, inherited, or delegated to sub-flows) ----
+	if len(scope.notify) == 0 && len(f.Flows) == 0 {
+		diags = append(diags, f.Pos.Locate(diag.Diagnostic{
 			Level:   diag.LevelError,
 			Code:    "FLOW_NOTIFY_EMPTY",
-			Message: fmt.Sprintf("flows[%d] has no notify target(s)", idx),
-			File:    team.Path, // we don't have exact file/line yet
-		})
+			Message: fmt.Sprintf("%s has no notify target(s)", path),
+			File:    team.Path, // fallback when the flow has no position
+		}))
 		return routes, diags
 	}
 
@@ -50,21 +111,86 @@ func mapFlowToRoutes(team dsl.Team, idx int, f dsl.Flow) ([]am.Route, []diag.Dia
 		diags = append(diags, mDiags...)
 	}
 
+	mute := windowNames(proj, team, scope.silenceWhen, opts)
 	r := am.Route{
-		Receiver:       f.Notify,
-		GroupBy:        append([]string{}, f.GroupBy...),
-		GroupWait:      f.WaitFor,
-		GroupInterval:  f.GroupInterval,
-		RepeatInterval: f.RepeatAfter,
-		Matchers:       matchers,
-		TimeIntervals:  append([]string{}, f.SilenceWhen...),
+		GroupBy:           cloneSlice(f.GroupBy),
+		GroupWait:         f.WaitFor,
+		GroupInterval:     f.GroupInterval,
+		RepeatInterval:    f.RepeatAfter,
+		Matchers:          matchers,
+		MuteTimeIntervals: mute,
+		Source:            "team/" + team.Name + " " + path,
+	}
+	if len(scope.notify) > 0 {
+		r.Receiver = scope.notify[0]
 	}
 
 	if f.Continue != nil {
 		r.Continue = *f.Continue
 	}
 
+	// ---- sub-flows: tried first, in order ----
+	for i, sub := range f.Flows {
+		rs, d := mapFlowToRoutes(proj, team, fmt.Sprintf("%s.flows[%d]", path, i), sub, scope, opts)
+		diags = append(diags, d...)
+		r.Routes = append(r.Routes, rs...)
+	}
+
+	// ---- several targets: one parent route, one child per target ----
+	// Children carry no matchers and inherit grouping and timing from the
+	// parent, so every target receives each alert the parent matches that
+	// no sub-flow claimed. Alertmanager does not inherit time intervals, so
+	// children repeat them.
+	if len(scope.notify) > 1 {
+		r.Routes = append(r.Routes, fanOut(scope.notify, mute, r.Source)...)
+	}
+
 	routes = append(routes, r)
 
 	return routes, diags
 }
+
+// receiverNames maps a team's notify targets, which may name global
+// channels, to receiver names.
+func receiverNames(team dsl.Team, targets dsl.Targets, opts Options) []string {
+	if len(targets) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(targets))
+	for _, t := range targets {
+		out = append(out, dsl.TargetReceiverName(opts.ReceiverPattern, team.Name, t))
+	}
+	return out
+}
+
+// windowNames maps a team's silence_when references to time interval names.
+// Disabled windows are dropped, as they are not rendered; unknown names are
+// kept as written for validation to report.
+func windowNames(proj dsl.Project, team dsl.Team, names []string, opts Options) []string {
+	var out []string
+	for _, n := range names {
+		sw, scope, ok := proj.LookupWindow(team, n)
+		switch {
+		case !ok:
+			out = append(out, n)
+		case sw.Enabled:
+			out = append(out, dsl.WindowName(opts.ReceiverPattern, scope, n))
+		}
+	}
+	return out
+}
+
+// fanOut returns catch-all child routes delivering to every target in order.
+// source is recorded as each child's provenance.
+func fanOut(targets []string, silenceWhen []string, source string) []am.Route {
+	routes := make([]am.Route, 0, len(targets))
+	for i, target := range targets {
+		routes = append(routes, am.Route{
+			Receiver:          target,
+			MuteTimeIntervals: cloneSlice(silenceWhen),
+			Continue:          i < len(targets)-1,
+			Source:            source,
+		})
+	}
+	return routes
+}
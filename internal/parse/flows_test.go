@@ -0,0 +1,439 @@
+package parse_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// flowRoutes builds the routes of a single payments flow, i.e. the children
+// of the team's parent route.
+func flowRoutes(t *testing.T, f dsl.Flow) []am.Route {
+	t.Helper()
+	proj := dsl.Project{
+		SilenceWindows: []dsl.SilenceWindow{window("nights", true), window("weekends", true)},
+		Teams:          []dsl.Team{{Name: "payments", Flows: []dsl.Flow{f}}},
+	}
+	root, diags := parse.BuildFlowRoutes(proj, parse.Options{})
+	require.Empty(t, diags)
+	require.Len(t, root.Routes, 1)
+	return root.Routes[0].Routes
+}
+
+func TestBuildFlowRoutes_FanOut(t *testing.T) {
+	critical := dsl.Matchers{eq("severity", "critical")}
+	const src = "team/payments flows[0]"
+
+	tests := []struct {
+		name string
+		flow dsl.Flow
+		want []am.Route
+	}{
+		{
+			name: "single target",
+			flow: dsl.Flow{When: critical, Notify: dsl.Targets{"slack"}},
+			want: []am.Route{{
+				Receiver: "payments/slack",
+				Matchers: []string{`severity = "critical"`},
+				Source:   src,
+			}},
+		},
+		{
+			name: "several targets continue on all but the last",
+			flow: dsl.Flow{When: critical, Notify: dsl.Targets{"slack", "pager", "global:sre"}},
+			want: []am.Route{{
+				Receiver: "payments/slack",
+				Matchers: []string{`severity = "critical"`},
+				Source:   src,
+				Routes: []am.Route{
+					{Receiver: "payments/slack", Continue: true, Source: src},
+					{Receiver: "payments/pager", Continue: true, Source: src},
+					{Receiver: "global/sre", Source: src},
+				},
+			}},
+		},
+		{
+			name: "fan-out children repeat the silence windows",
+			flow: dsl.Flow{
+				When:        critical,
+				Notify:      dsl.Targets{"slack", "pager"},
+				SilenceWhen: []string{"nights"},
+				WaitFor:     "1m",
+				Continue:    boolPtr(true),
+			},
+			want: []am.Route{{
+				Receiver:          "payments/slack",
+				GroupWait:         "1m",
+				Matchers:          []string{`severity = "critical"`},
+				MuteTimeIntervals: []string{"global/nights"},
+				Continue:          true,
+				Source:            src,
+				Routes: []am.Route{
+					{Receiver: "payments/slack", MuteTimeIntervals: []string{"global/nights"}, Continue: true, Source: src},
+					{Receiver: "payments/pager", MuteTimeIntervals: []string{"global/nights"}, Source: src},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flowRoutes(t, tt.flow))
+		})
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestBuildFlowRoutes_SubFlows(t *testing.T) {
+	const src = "team/payments flows[0]"
+
+	tests := []struct {
+		name string
+		flow dsl.Flow
+		want []am.Route
+	}{
+		{
+			name: "sub-flows inherit notify and silence_when",
+			flow: dsl.Flow{
+				When:        dsl.Matchers{eq("service", "api")},
+				Notify:      dsl.Targets{"slack"},
+				SilenceWhen: []string{"nights"},
+				GroupBy:     []string{"alertname"},
+				Flows: []dsl.Flow{
+					{When: dsl.Matchers{eq("severity", "critical")}},
+				},
+			},
+			want: []am.Route{{
+				Receiver:          "payments/slack",
+				GroupBy:           []string{"alertname"},
+				Matchers:          []string{`service = "api"`},
+				MuteTimeIntervals: []string{"global/nights"},
+				Source:            src,
+				Routes: []am.Route{{
+					Receiver:          "payments/slack",
+					Matchers:          []string{`severity = "critical"`},
+					MuteTimeIntervals: []string{"global/nights"},
+					Source:            src + ".flows[0]",
+				}},
+			}},
+		},
+		{
+			name: "sub-flow overrides notify and silence_when",
+			flow: dsl.Flow{
+				When:        dsl.Matchers{eq("service", "api")},
+				Notify:      dsl.Targets{"slack"},
+				SilenceWhen: []string{"nights"},
+				Flows: []dsl.Flow{{
+					When:        dsl.Matchers{eq("severity", "critical")},
+					Notify:      dsl.Targets{"pager"},
+					SilenceWhen: []string{"weekends"},
+				}},
+			},
+			want: []am.Route{{
+				Receiver:          "payments/slack",
+				Matchers:          []string{`service = "api"`},
+				MuteTimeIntervals: []string{"global/nights"},
+				Source:            src,
+				Routes: []am.Route{{
+					Receiver:          "payments/pager",
+					Matchers:          []string{`severity = "critical"`},
+					MuteTimeIntervals: []string{"global/weekends"},
+					Source:            src + ".flows[0]",
+				}},
+			}},
+		},
+		{
+			name: "empty silence_when clears the inherited windows",
+			flow: dsl.Flow{
+				When:        dsl.Matchers{eq("service", "api")},
+				Notify:      dsl.Targets{"slack"},
+				SilenceWhen: []string{"nights"},
+				Flows: []dsl.Flow{{
+					When:        dsl.Matchers{eq("severity", "critical")},
+					SilenceWhen: []string{},
+				}},
+			},
+			want: []am.Route{{
+				Receiver:          "payments/slack",
+				Matchers:          []string{`service = "api"`},
+				MuteTimeIntervals: []string{"global/nights"},
+				Source:            src,
+				Routes: []am.Route{{
+					Receiver: "payments/slack",
+					Matchers: []string{`severity = "critical"`},
+					Source:   src + ".flows[0]",
+				}},
+			}},
+		},
+		{
+			name: "inherited targets fan out below the sub-flow",
+			flow: dsl.Flow{
+				When:   dsl.Matchers{eq("service", "api")},
+				Notify: dsl.Targets{"slack", "pager"},
+				Flows: []dsl.Flow{
+					{When: dsl.Matchers{eq("severity", "critical")}},
+				},
+			},
+			want: []am.Route{{
+				Receiver: "payments/slack",
+				Matchers: []string{`service = "api"`},
+				Source:   src,
+				Routes: []am.Route{
+					{
+						Receiver: "payments/slack",
+						Matchers: []string{`severity = "critical"`},
+						Source:   src + ".flows[0]",
+						Routes: []am.Route{
+							{Receiver: "payments/slack", Continue: true, Source: src + ".flows[0]"},
+							{Receiver: "payments/pager", Source: src + ".flows[0]"},
+						},
+					},
+					{Receiver: "payments/slack", Continue: true, Source: src},
+					{Receiver: "payments/pager", Source: src},
+				},
+			}},
+		},
+		{
+			name: "parent without notify delegates to its sub-flows",
+			flow: dsl.Flow{
+				When: dsl.Matchers{eq("service", "api")},
+				Flows: []dsl.Flow{
+					{When: dsl.Matchers{eq("severity", "critical")}, Notify: dsl.Targets{"pager"}},
+				},
+			},
+			want: []am.Route{{
+				Matchers: []string{`service = "api"`},
+				Source:   src,
+				Routes: []am.Route{{
+					Receiver: "payments/pager",
+					Matchers: []string{`severity = "critical"`},
+					Source:   src + ".flows[0]",
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flowRoutes(t, tt.flow))
+		})
+	}
+}
+
+// silence_when: [] must survive decoding as an empty, non-nil list, or the
+// sub-flow would inherit its parent's windows instead of clearing them.
+func TestBuildFlowRoutes_SilenceWhenClearedInYAML(t *testing.T) {
+	var f dsl.Flow
+	require.NoError(t, yaml.Unmarshal([]byte(`
+when: {service: api}
+notify: slack
+silence_when: [nights]
+flows:
+  - when: {severity: critical}
+    silence_when: []
+`), &f))
+
+	routes := flowRoutes(t, f)
+	require.Len(t, routes, 1)
+	require.Len(t, routes[0].Routes, 1)
+	assert.Equal(t, []string{"global/nights"}, routes[0].MuteTimeIntervals)
+	assert.Empty(t, routes[0].Routes[0].MuteTimeIntervals)
+}
+
+func TestBuildFlowRoutes_TeamRoute(t *testing.T) {
+	critical := dsl.Flow{When: dsl.Matchers{eq("severity", "critical")}, Notify: dsl.Targets{"pager"}}
+	criticalRoute := am.Route{
+		Receiver: "payments/pager",
+		Matchers: []string{`severity = "critical"`},
+		Source:   "team/payments flows[0]",
+	}
+
+	tests := []struct {
+		name string
+		team dsl.Team
+		want []am.Route // children of the root route
+	}{
+		{
+			name: "flows nest under the ownership route",
+			team: dsl.Team{Name: "payments", Flows: []dsl.Flow{critical}},
+			want: []am.Route{{
+				Matchers: []string{`team = "payments"`},
+				Source:   "team/payments",
+				Routes:   []am.Route{criticalRoute},
+			}},
+		},
+		{
+			name: "default_notify receives what no flow matches",
+			team: dsl.Team{Name: "payments", DefaultNotify: dsl.Targets{"slack"}, Flows: []dsl.Flow{critical}},
+			want: []am.Route{{
+				Receiver: "payments/slack",
+				Matchers: []string{`team = "payments"`},
+				Source:   "team/payments",
+				Routes:   []am.Route{criticalRoute},
+			}},
+		},
+		{
+			name: "several default targets fan out after the flows",
+			team: dsl.Team{Name: "payments", DefaultNotify: dsl.Targets{"slack", "global:sre"}, Flows: []dsl.Flow{critical}},
+			want: []am.Route{{
+				Receiver: "payments/slack",
+				Matchers: []string{`team = "payments"`},
+				Source:   "team/payments",
+				Routes: []am.Route{
+					criticalRoute,
+					{Receiver: "payments/slack", Continue: true, Source: "team/payments default_notify"},
+					{Receiver: "global/sre", Source: "team/payments default_notify"},
+				},
+			}},
+		},
+		{
+			name: "flows inherit default_notify",
+			team: dsl.Team{
+				Name:          "payments",
+				DefaultNotify: dsl.Targets{"slack"},
+				Flows:         []dsl.Flow{{When: dsl.Matchers{eq("severity", "warning")}}},
+			},
+			want: []am.Route{{
+				Receiver: "payments/slack",
+				Matchers: []string{`team = "payments"`},
+				Source:   "team/payments",
+				Routes: []am.Route{{
+					Receiver: "payments/slack",
+					Matchers: []string{`severity = "warning"`},
+					Source:   "team/payments flows[0]",
+				}},
+			}},
+		},
+		{
+			name: "custom ownership matchers",
+			team: dsl.Team{
+				Name:      "payments",
+				Ownership: dsl.Matchers{eq("squad", "billing"), {Label: "env", Op: "!=", Value: "dev"}},
+				Flows:     []dsl.Flow{critical},
+			},
+			want: []am.Route{{
+				Matchers: []string{`squad = "billing"`, `env != "dev"`},
+				Source:   "team/payments",
+				Routes:   []am.Route{criticalRoute},
+			}},
+		},
+		{
+			name: "team with nothing to route is left out",
+			team: dsl.Team{Name: "payments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, diags := parse.BuildFlowRoutes(dsl.Project{
+				RootRoute: am.Route{Receiver: "global:default"},
+				Teams:     []dsl.Team{tt.team},
+			}, parse.Options{})
+			require.Empty(t, diags)
+			assert.Equal(t, "global/default", root.Receiver)
+			assert.Equal(t, tt.want, root.Routes)
+		})
+	}
+}
+
+// Routes written by hand in root_route.yaml name channels and windows the way
+// flows do; bare channel names, the spelling before receivers were namespaced,
+// are rewritten with a warning pointing at the route.
+func TestBuildFlowRoutes_RootRouteTree(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"global/global.yaml": "global: {}\n",
+		"global/channels.yaml": `channels:
+  - name: default
+    type: webhook
+    configs:
+      - url: http://127.0.0.1:5001/
+  - name: sre
+    type: webhook
+    configs:
+      - url: http://127.0.0.1:5002/
+`,
+		"global/silence_windows.yaml": `silence_windows:
+  - name: nights
+    enabled: true
+    time: "22:00-06:00"
+  - name: holidays
+    enabled: false
+    months: [december]
+`,
+		"global/root_route.yaml": `route:
+  receiver: global:default
+  routes:
+    - matchers: ['team = "sre"']
+      receiver: global:sre
+      mute_time_intervals: [nights, holidays]
+      routes:
+        - matchers: ['severity = "info"']
+          receiver: hook
+          active_time_intervals: [office]
+    - matchers: ['team = "legacy"']
+      receiver: sre
+`,
+		"teams/payments/channels.yaml": `channels:
+  - name: hook
+    type: webhook
+    configs:
+      - url: http://127.0.0.1:5003/
+`,
+		"teams/payments/flows.yaml": "flows: []\n",
+		"teams/payments/silence_windows.yaml": `silence_windows:
+  - name: office
+    enabled: true
+    time: "09:00-17:00"
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	proj, loadDiags := dsl.LoadProject(root, nil)
+	require.Empty(t, loadDiags)
+
+	got, diags := parse.BuildFlowRoutes(proj, parse.Options{})
+	assert.Equal(t, am.Route{
+		Receiver: "global/default",
+		Routes: []am.Route{
+			{
+				Matchers:          []string{`team = "sre"`},
+				Receiver:          "global/sre",
+				MuteTimeIntervals: []string{"global/nights"},
+				Routes: []am.Route{{
+					Matchers:            []string{`severity = "info"`},
+					Receiver:            "payments/hook",
+					ActiveTimeIntervals: []string{"payments/office"},
+				}},
+			},
+			{Matchers: []string{`team = "legacy"`}, Receiver: "global/sre"},
+		},
+	}, got)
+
+	rootRoute := filepath.Join(root, "global", "root_route.yaml")
+	type finding struct {
+		Code, Message, File string
+		Line                int
+	}
+	var findings []finding
+	for _, d := range diags {
+		findings = append(findings, finding{d.Code, d.Message, d.File, d.Line})
+	}
+	assert.Equal(t, []finding{
+		{"ROOT_ROUTE_NAME_OUTDATED", `route.routes[0].routes[0].receiver refers to channel "hook" by its bare name; write "payments/hook"`, rootRoute, 3},
+		{"ROOT_ROUTE_NAME_OUTDATED", `route.routes[0].routes[0].active_time_intervals refers to silence window "office" by its bare name; write "payments/office"`, rootRoute, 3},
+		{"ROOT_ROUTE_NAME_OUTDATED", `route.routes[1].receiver refers to channel "sre" by its bare name; write "global:sre"`, rootRoute, 3},
+	}, findings)
+}
@@ -0,0 +1,83 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// BuildInhibitRules maps global and team inhibitors into AM inhibit_rules.
+// Global inhibitors are copied as-is. Team inhibitors are scoped to the team
+// by adding its ownership matchers to both source and target, so one team's
+// rule can never suppress another team's alerts.
+func BuildInhibitRules(proj dsl.Project) ([]am.InhibitRule, []diag.Diagnostic) {
+	var (
+		rules []am.InhibitRule
+		diags []diag.Diagnostic
+	)
+
+	build := func(ir dsl.Inhibitor, source, target []dsl.Matcher) am.InhibitRule {
+		sm, d := ToMatchers(source)
+		diags = append(diags, d...)
+		tm, d := ToMatchers(target)
+		diags = append(diags, d...)
+		return am.InhibitRule{
+			SourceMatchers: sm,
+			TargetMatchers: tm,
+			Equal:          cloneSlice(ir.When),
+		}
+	}
+
+	for _, ir := range proj.Inhibitors {
+		rules = append(rules, build(ir, ir.If, ir.Suppress))
+	}
+
+	for _, team := range proj.Teams {
+		for _, ir := range team.Inhibitors {
+			source, d := scopeToTeam(team, ir, "if", ir.If)
+			diags = append(diags, d...)
+			target, d := scopeToTeam(team, ir, "suppress", ir.Suppress)
+			diags = append(diags, d...)
+
+			rules = append(rules, build(ir, source, target))
+		}
+	}
+
+	return rules, diags
+}
+
+// scopeToTeam returns a copy of ms with the team's ownership matchers set.
+// Other matchers on an ownership label are replaced, with a warning unless
+// they are one of the ownership matchers already.
+func scopeToTeam(team dsl.Team, ir dsl.Inhibitor, field string, ms dsl.Matchers) ([]dsl.Matcher, []diag.Diagnostic) {
+	var diags []diag.Diagnostic
+
+	owner := team.OwnershipMatchers()
+	ownLabels := make(map[string]struct{}, len(owner))
+	ownMatchers := make(map[dsl.Matcher]struct{}, len(owner))
+	for _, m := range owner {
+		ownLabels[m.Label] = struct{}{}
+		ownMatchers[m] = struct{}{}
+	}
+
+	out := make([]dsl.Matcher, 0, len(ms)+len(owner))
+	for _, m := range ms {
+		if _, ok := ownLabels[m.Label]; !ok {
+			out = append(out, m)
+			continue
+		}
+		if _, ok := ownMatchers[m]; !ok {
+			diags = append(diags, ir.KeyPos(field).Locate(diag.Diagnostic{
+				Level: diag.LevelWarn,
+				Code:  "INHIBITOR_TEAM_SCOPE",
+				Message: fmt.Sprintf("team %q inhibitor %q matches %s%s%q in '%s'; team inhibitors only apply to the team's own alerts, using the team's ownership matchers",
+					team.Name, ir.Name, m.Label, m.Op, m.Value, field),
+				File: team.Path,
+			}))
+		}
+	}
+
+	return append(out, owner...), diags
+}
@@ -0,0 +1,173 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func eq(label, value string) dsl.Matcher {
+	return dsl.Matcher{Label: label, Op: "=", Value: value}
+}
+
+func TestBuildInhibitRules(t *testing.T) {
+	critical := dsl.Inhibitor{
+		Name:     "critical-mutes-warning",
+		If:       dsl.Matchers{eq("severity", "critical")},
+		Suppress: dsl.Matchers{eq("severity", "warning")},
+		When:     []string{"service"},
+	}
+
+	tests := []struct {
+		name      string
+		proj      dsl.Project
+		want      []am.InhibitRule
+		wantCodes []string
+	}{
+		{
+			name: "global inhibitor is copied as-is",
+			proj: dsl.Project{Inhibitors: []dsl.Inhibitor{critical}},
+			want: []am.InhibitRule{{
+				SourceMatchers: []string{`severity = "critical"`},
+				TargetMatchers: []string{`severity = "warning"`},
+				Equal:          []string{"service"},
+			}},
+		},
+		{
+			name: "team inhibitor is scoped on both sides",
+			proj: dsl.Project{Teams: []dsl.Team{
+				{Name: "payments", Inhibitors: []dsl.Inhibitor{critical}},
+			}},
+			want: []am.InhibitRule{{
+				SourceMatchers: []string{`severity = "critical"`, `team = "payments"`},
+				TargetMatchers: []string{`severity = "warning"`, `team = "payments"`},
+				Equal:          []string{"service"},
+			}},
+		},
+		{
+			name: "custom ownership matchers scope the rule",
+			proj: dsl.Project{Teams: []dsl.Team{{
+				Name:       "payments",
+				Ownership:  dsl.Matchers{eq("squad", "billing"), {Label: "env", Op: "=~", Value: "prod|staging"}},
+				Inhibitors: []dsl.Inhibitor{critical},
+			}}},
+			want: []am.InhibitRule{{
+				SourceMatchers: []string{`severity = "critical"`, `squad = "billing"`, `env =~ "prod|staging"`},
+				TargetMatchers: []string{`severity = "warning"`, `squad = "billing"`, `env =~ "prod|staging"`},
+				Equal:          []string{"service"},
+			}},
+		},
+		{
+			name: "matcher on another team is replaced with a warning",
+			proj: dsl.Project{Teams: []dsl.Team{{
+				Name: "payments",
+				Inhibitors: []dsl.Inhibitor{{
+					Name:     "mute-search",
+					If:       dsl.Matchers{eq("alertname", "PaymentsDown")},
+					Suppress: dsl.Matchers{eq("team", "search")},
+				}},
+			}}},
+			want: []am.InhibitRule{{
+				SourceMatchers: []string{`alertname = "PaymentsDown"`, `team = "payments"`},
+				TargetMatchers: []string{`team = "payments"`},
+			}},
+			wantCodes: []string{"INHIBITOR_TEAM_SCOPE"},
+		},
+		{
+			name: "regex on the ownership label is replaced with a warning",
+			proj: dsl.Project{Teams: []dsl.Team{{
+				Name: "payments",
+				Inhibitors: []dsl.Inhibitor{{
+					Name:     "mute-everyone",
+					If:       dsl.Matchers{{Label: "team", Op: "=~", Value: ".*"}},
+					Suppress: dsl.Matchers{{Label: "team", Op: "=~", Value: ".*"}},
+				}},
+			}}},
+			want: []am.InhibitRule{{
+				SourceMatchers: []string{`team = "payments"`},
+				TargetMatchers: []string{`team = "payments"`},
+			}},
+			wantCodes: []string{"INHIBITOR_TEAM_SCOPE", "INHIBITOR_TEAM_SCOPE"},
+		},
+		{
+			name: "restating the team's own matcher is not a warning",
+			proj: dsl.Project{Teams: []dsl.Team{{
+				Name: "payments",
+				Inhibitors: []dsl.Inhibitor{{
+					If:       dsl.Matchers{eq("team", "payments"), eq("severity", "critical")},
+					Suppress: dsl.Matchers{eq("severity", "warning")},
+				}},
+			}}},
+			want: []am.InhibitRule{{
+				SourceMatchers: []string{`severity = "critical"`, `team = "payments"`},
+				TargetMatchers: []string{`severity = "warning"`, `team = "payments"`},
+			}},
+		},
+		{
+			name: "each team is scoped to itself",
+			proj: dsl.Project{Teams: []dsl.Team{
+				{Name: "payments", Inhibitors: []dsl.Inhibitor{critical}},
+				{Name: "search", Inhibitors: []dsl.Inhibitor{critical}},
+			}},
+			want: []am.InhibitRule{
+				{
+					SourceMatchers: []string{`severity = "critical"`, `team = "payments"`},
+					TargetMatchers: []string{`severity = "warning"`, `team = "payments"`},
+					Equal:          []string{"service"},
+				},
+				{
+					SourceMatchers: []string{`severity = "critical"`, `team = "search"`},
+					TargetMatchers: []string{`severity = "warning"`, `team = "search"`},
+					Equal:          []string{"service"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, diags := parse.BuildInhibitRules(tt.proj)
+			assert.Equal(t, tt.want, rules)
+
+			var codes []string
+			for _, d := range diags {
+				codes = append(codes, d.Code)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+}
+
+// A team inhibitor must not match another team's alerts as a target, even
+// when it tries to.
+func TestBuildInhibitRules_OtherTeamsUnaffected(t *testing.T) {
+	proj := dsl.Project{Teams: []dsl.Team{{
+		Name: "payments",
+		Inhibitors: []dsl.Inhibitor{{
+			Name:     "mute-search",
+			If:       dsl.Matchers{eq("alertname", "PaymentsDown")},
+			Suppress: dsl.Matchers{eq("team", "search")},
+		}},
+	}}}
+
+	rules, _ := parse.BuildInhibitRules(proj)
+	require.Len(t, rules, 1)
+
+	matches := func(matchers []string, labels map[string]string) bool {
+		for _, s := range matchers {
+			m, err := am.ParseMatcher(s)
+			assert.NoError(t, err)
+			if !m.Matches(labels[m.Name]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	assert.False(t, matches(rules[0].TargetMatchers, map[string]string{"team": "search", "severity": "warning"}))
+	assert.True(t, matches(rules[0].TargetMatchers, map[string]string{"team": "payments", "severity": "warning"}))
+}
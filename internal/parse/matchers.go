@@ -19,7 +19,7 @@ func ToMatchers(when []dsl.Matcher) ([]string, []diag.Diagnostic) {
 	)
 
 	for _, matcher := range when {
-		m := fmt.Sprintf("%s %s \"%s\"", matcher.Label, matcher.Op, matcher.Value)
+		m := fmt.Sprintf("%s %s \"%s\"", matcher.Label, matcher.Op, escapeQuotes(matcher.Value))
 		if m != "" {
 			out = append(out, m)
 		}
@@ -38,5 +38,6 @@ func validateRegex(p string) error {
 
 func escapeQuotes(s string) string {
 	// Minimal escaping for AM matcher string form.
+	s = strings.ReplaceAll(s, `\`, `\\`)
 	return strings.ReplaceAll(s, `"`, `\"`)
 }
@@ -7,21 +7,33 @@ import (
 	"github.com/nyambati/fuse/internal/secrets"
 )
 
+// Options controls how the DSL is translated.
+type Options struct {
+	// Strict turns unresolved secrets into errors instead of warnings.
+	Strict bool
+	// ReceiverPattern names team receivers and time intervals (see
+	// dsl.ReceiverName and dsl.WindowName).
+	ReceiverPattern string
+}
+
 // ToAlertmanager translates a loaded Fuse project into an Alertmanager config.
 //
 // MVP steps:
 //  1. Build Receivers from channels
 //  2. Build Routes from flows (attached under root route)
 //  3. Build TimeIntervals from silence_windows
-//  4. Inhibit rules are passed through as-is from DSL (v0.1 simple copy)
-func ToAlertmanager(proj dsl.Project, prov secrets.Provider) (am.Config, []diag.Diagnostic) {
+//  4. Build InhibitRules from global and team inhibitors
+//  5. List team notification templates
+//
+// Secrets referenced as ${VAR} in channel configs are resolved through prov.
+func ToAlertmanager(proj dsl.Project, prov secrets.Provider, opts Options) (am.Config, []diag.Diagnostic) {
 	var (
 		cfg   am.Config
 		diags []diag.Diagnostic
 	)
 
 	// Receivers
-	recvs, rDiags := BuildReceivers(proj)
+	recvs, rDiags := BuildReceivers(proj, prov, opts)
 	if len(rDiags) > 0 {
 		diags = append(diags, rDiags...)
 	}
@@ -29,7 +41,7 @@ func ToAlertmanager(proj dsl.Project, prov secrets.Provider) (am.Config, []diag.
 	cfg.Receivers = recvs
 
 	// // Routes (flows)
-	rootRoute, fDiags := BuildFlowRoutes(proj)
+	rootRoute, fDiags := BuildFlowRoutes(proj, opts)
 	if len(fDiags) > 0 {
 		diags = append(diags, fDiags...)
 	}
@@ -37,26 +49,24 @@ func ToAlertmanager(proj dsl.Project, prov secrets.Provider) (am.Config, []diag.
 	cfg.Route = rootRoute
 
 	// TimeIntervals (silence windows)
-	intervals, tDiags := BuildTimeIntervals(proj)
+	intervals, tDiags := BuildTimeIntervals(proj, opts)
 	if len(tDiags) > 0 {
 		diags = append(diags, tDiags...)
 	}
 	cfg.TimeIntervals = intervals
 
-	// InhibitRules — copy directly from DSL
-	for _, ir := range proj.Inhibitors {
-		cfg.InhibitRules = append(cfg.InhibitRules, am.InhibitRule{
-			SourceMatchers: ir.If,
-			TargetMatchers: ir.Suppress,
-			Equal:          ir.When,
-		})
+	// InhibitRules (global as-is, team rules scoped to the team)
+	rules, iDiags := BuildInhibitRules(proj)
+	if len(iDiags) > 0 {
+		diags = append(diags, iDiags...)
 	}
+	cfg.InhibitRules = rules
+
+	// Templates (files are copied next to the config by `fuse build`)
+	cfg.Templates = BuildTemplates(proj)
 
 	// Global config (from DSL global section) — MVP: straight copy
 	cfg.Global = proj.Global
 
-	// TODO (later): secrets resolution via prov
-	_ = prov // currently unused until secrets resolution is added
-
 	return cfg, diags
 }
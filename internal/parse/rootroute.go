@@ -0,0 +1,186 @@
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// rootNames maps the receiver and time interval names written in
+// global/root_route.yaml to the names they are rendered as.
+type rootNames struct {
+	pattern string
+	// receivers and intervals hold the rendered names, which are kept as
+	// written.
+	receivers map[string]struct{}
+	intervals map[string]struct{}
+	// channels maps a bare channel name to the channels of that name, and
+	// windows a bare window name to the team windows of that name.
+	channels map[string][]rootRef
+	windows  map[string][]rootRef
+	global   map[string]dsl.SilenceWindow
+}
+
+// rootRef is a channel or silence window a bare name may refer to. spelling
+// is how the root route should name it.
+type rootRef struct {
+	name     string
+	spelling string
+	enabled  bool
+}
+
+func newRootNames(proj dsl.Project, opts Options) rootNames {
+	n := rootNames{
+		pattern:   opts.ReceiverPattern,
+		receivers: map[string]struct{}{},
+		intervals: map[string]struct{}{},
+		channels:  map[string][]rootRef{},
+		windows:   map[string][]rootRef{},
+		global:    map[string]dsl.SilenceWindow{},
+	}
+	for _, t := range append([]dsl.Team{proj.GlobalTeam()}, proj.Teams...) {
+		for _, ch := range t.Channels {
+			name := dsl.ReceiverName(n.pattern, t.Name, ch.Name)
+			spelling := name
+			if t.Name == dsl.GlobalScope {
+				spelling = dsl.GlobalScope + ":" + ch.Name
+			}
+			n.receivers[name] = struct{}{}
+			n.channels[ch.Name] = append(n.channels[ch.Name], rootRef{name: name, spelling: spelling})
+		}
+	}
+	for _, sw := range proj.SilenceWindows {
+		n.intervals[dsl.WindowName(n.pattern, dsl.GlobalScope, sw.Name)] = struct{}{}
+		if _, dup := n.global[sw.Name]; !dup {
+			n.global[sw.Name] = sw
+		}
+	}
+	for _, t := range proj.Teams {
+		for _, sw := range t.SilenceWindows {
+			name := dsl.WindowName(n.pattern, t.Name, sw.Name)
+			n.intervals[name] = struct{}{}
+			n.windows[sw.Name] = append(n.windows[sw.Name], rootRef{name: name, spelling: name, enabled: sw.Enabled})
+		}
+	}
+	return n
+}
+
+// rewriteRootRoute renders the names used by the root route and the child
+// routes written under it in global/root_route.yaml. As in flows,
+// "global:<channel>" names a global channel and a bare time interval name a
+// global silence window; disabled windows are dropped. A bare channel name,
+// or the bare name of a team window, is rewritten when it names exactly one
+// channel or window, with a warning giving the new spelling. Other names are
+// kept for validation to report. proj is not modified.
+func rewriteRootRoute(proj dsl.Project, opts Options) (am.Route, []diag.Diagnostic) {
+	return newRootNames(proj, opts).route(proj.RootRoute, "route", proj.RootRouteFile)
+}
+
+// route rewrites r and its children; path names r in diagnostics and src
+// locates it.
+func (n rootNames) route(r am.Route, path string, src dsl.Source) (am.Route, []diag.Diagnostic) {
+	var diags []diag.Diagnostic
+
+	if r.Receiver != "" {
+		var d []diag.Diagnostic
+		r.Receiver, d = n.receiver(r.Receiver, path+".receiver", src.KeyPos("receiver"))
+		diags = append(diags, d...)
+	}
+
+	var d []diag.Diagnostic
+	r.MuteTimeIntervals, d = n.intervalNames(r.MuteTimeIntervals, path+".mute_time_intervals", src.KeyPos("mute_time_intervals"))
+	diags = append(diags, d...)
+	r.ActiveTimeIntervals, d = n.intervalNames(r.ActiveTimeIntervals, path+".active_time_intervals", src.KeyPos("active_time_intervals"))
+	diags = append(diags, d...)
+
+	// Child routes are located at the routes key, as in the validators.
+	if len(r.Routes) > 0 {
+		child := dsl.Source{Pos: src.KeyPos("routes")}
+		routes := make([]am.Route, 0, len(r.Routes))
+		for i, c := range r.Routes {
+			c, d := n.route(c, fmt.Sprintf("%s.routes[%d]", path, i), child)
+			diags = append(diags, d...)
+			routes = append(routes, c)
+		}
+		r.Routes = routes
+	}
+
+	return r, diags
+}
+
+func (n rootNames) receiver(name, field string, pos dsl.Pos) (string, []diag.Diagnostic) {
+	if ch, global := dsl.ParseTarget(name); global {
+		return dsl.ReceiverName(n.pattern, dsl.GlobalScope, ch), nil
+	}
+	if _, ok := n.receivers[name]; ok {
+		return name, nil
+	}
+	refs := n.channels[name]
+	if len(refs) == 0 {
+		return name, nil
+	}
+	d := outdatedName(field, "channel", name, refs, pos)
+	if len(refs) > 1 {
+		return name, []diag.Diagnostic{d}
+	}
+	return refs[0].name, []diag.Diagnostic{d}
+}
+
+func (n rootNames) intervalNames(names []string, field string, pos dsl.Pos) ([]string, []diag.Diagnostic) {
+	if len(names) == 0 {
+		return names, nil
+	}
+	var (
+		out   []string
+		diags []diag.Diagnostic
+	)
+	for _, name := range names {
+		if _, ok := n.intervals[name]; ok {
+			out = append(out, name)
+			continue
+		}
+		if sw, ok := n.global[name]; ok {
+			if sw.Enabled {
+				out = append(out, dsl.WindowName(n.pattern, dsl.GlobalScope, name))
+			}
+			continue
+		}
+		refs := n.windows[name]
+		if len(refs) == 0 {
+			out = append(out, name)
+			continue
+		}
+		diags = append(diags, outdatedName(field, "silence window", name, refs, pos))
+		switch {
+		case len(refs) > 1:
+			out = append(out, name)
+		case refs[0].enabled:
+			out = append(out, refs[0].name)
+		}
+	}
+	return out, diags
+}
+
+// outdatedName reports a bare name that no longer matches a rendered name,
+// with the spelling to use instead.
+func outdatedName(field, kind, name string, refs []rootRef, pos dsl.Pos) diag.Diagnostic {
+	if len(refs) == 1 {
+		return pos.Locate(diag.Diagnostic{
+			Level:   diag.LevelWarn,
+			Code:    "ROOT_ROUTE_NAME_OUTDATED",
+			Message: fmt.Sprintf("%s refers to %s %q by its bare name; write %q", field, kind, name, refs[0].spelling),
+		})
+	}
+	spellings := make([]string, 0, len(refs))
+	for _, r := range refs {
+		spellings = append(spellings, fmt.Sprintf("%q", r.spelling))
+	}
+	return pos.Locate(diag.Diagnostic{
+		Level:   diag.LevelError,
+		Code:    "ROOT_ROUTE_NAME_AMBIGUOUS",
+		Message: fmt.Sprintf("%s refers to %s %q by its bare name, which matches several; write one of %s", field, kind, name, strings.Join(spellings, ", ")),
+	})
+}
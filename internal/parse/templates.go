@@ -0,0 +1,43 @@
+package parse
+
+import (
+	"path"
+
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// TemplateDir is the directory, next to the rendered config, that team
+// templates are written to. It may hold other files too: fuse build only
+// replaces or removes the templates it wrote itself.
+const TemplateDir = "templates"
+
+// TemplateFile returns where a team template is written, relative to the
+// directory of the rendered config. Each team gets its own directory so
+// files with the same name in different teams do not overwrite each other.
+func TemplateFile(team dsl.Team, t dsl.Template) string {
+	return path.Join(TemplateDir, team.Name, t.Name)
+}
+
+// BuildTemplates lists every team template for the config's templates section.
+// Alertmanager resolves the paths relative to the config file.
+func BuildTemplates(proj dsl.Project) []string {
+	var out []string
+	for _, team := range proj.Teams {
+		for _, t := range team.Templates {
+			out = append(out, TemplateFile(team, t))
+		}
+	}
+	return out
+}
+
+// TemplateFiles returns the contents of every team template keyed by
+// TemplateFile, ready to be written next to the rendered config.
+func TemplateFiles(proj dsl.Project) map[string][]byte {
+	files := map[string][]byte{}
+	for _, team := range proj.Teams {
+		for _, t := range team.Templates {
+			files[TemplateFile(team, t)] = []byte(t.Content)
+		}
+	}
+	return files
+}
@@ -13,43 +13,42 @@ import (
 var timeRangeRe = regexp.MustCompile(`^\s*([0-2]?\d:[0-5]\d)\s*-\s*([0-2]?\d:[0-5]\d)\s*$`)
 
 // BuildTimeIntervals maps global + team silence_windows into AM time_intervals.
-// Team-level windows can shadow global ones with the same name (warn).
-func BuildTimeIntervals(proj dsl.Project) ([]am.TimeIntervalSet, []diag.Diagnostic) {
+// Each window is named after its owner with dsl.WindowName, so teams can use
+// the same window names. A name repeated within one owner is rendered once;
+// the validators report the duplicate.
+func BuildTimeIntervals(proj dsl.Project, opts Options) ([]am.TimeIntervalSet, []diag.Diagnostic) {
 	var (
 		sets  []am.TimeIntervalSet
 		diags []diag.Diagnostic
 	)
 
-	seen := map[string]string{} // name -> scope ("global" or "team/<name>")
+	seen := map[string]struct{}{} // rendered interval names
 
 	add := func(scope string, sw dsl.SilenceWindow) {
 		name := strings.TrimSpace(sw.Name)
 		if name == "" {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, sw.Pos.Locate(diag.Diagnostic{
 				Level:   diag.LevelError,
 				Code:    "SW_NAME_EMPTY",
 				Message: fmt.Sprintf("%s silence window has empty name", scope),
-			})
+			}))
 			return
 		}
 
 		if !sw.Enabled {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, sw.KeyPos("enabled").Locate(diag.Diagnostic{
 				Level:   diag.LevelInfo,
 				Code:    "SW_DISABLED",
 				Message: fmt.Sprintf("silence window %q is disabled; skipping", name),
-			})
+			}))
 			return
 		}
 
-		if prev, ok := seen[name]; ok && prev != scope {
-			diags = append(diags, diag.Diagnostic{
-				Level:   diag.LevelWarn,
-				Code:    "SW_SHADOW",
-				Message: fmt.Sprintf("silence window %q from %s shadows %s", name, scope, prev),
-			})
+		interval := dsl.WindowName(opts.ReceiverPattern, scope, name)
+		if _, dup := seen[interval]; dup {
+			return
 		}
-		seen[name] = scope
+		seen[interval] = struct{}{}
 
 		ti := am.TimeInterval{
 			Weekdays:    cloneSlice(sw.Weekdays),
@@ -63,11 +62,11 @@ func BuildTimeIntervals(proj dsl.Project) ([]am.TimeIntervalSet, []diag.Diagnost
 		if strings.TrimSpace(sw.Time) != "" {
 			m := timeRangeRe.FindStringSubmatch(sw.Time)
 			if len(m) != 3 {
-				diags = append(diags, diag.Diagnostic{
+				diags = append(diags, sw.KeyPos("time").Locate(diag.Diagnostic{
 					Level:   diag.LevelError,
 					Code:    "SW_TIME_FORMAT",
 					Message: fmt.Sprintf("silence window %q has invalid time range %q (expected HH:MM-HH:MM)", name, sw.Time),
-				})
+				}))
 				// continue building other fields; skip adding times
 			} else {
 				ti.Times = []struct {
@@ -85,27 +84,27 @@ func BuildTimeIntervals(proj dsl.Project) ([]am.TimeIntervalSet, []diag.Diagnost
 			len(ti.Months) == 0 &&
 			len(ti.Years) == 0 &&
 			len(ti.Times) == 0 {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, sw.Pos.Locate(diag.Diagnostic{
 				Level:   diag.LevelWarn,
 				Code:    "SW_EMPTY_INTERVAL",
 				Message: fmt.Sprintf("silence window %q has no constraints (weekdays/days/months/years/time); it would match everything", name),
-			})
+			}))
 		}
 
 		sets = append(sets, am.TimeIntervalSet{
-			Name:          name,
+			Name:          interval,
 			TimeIntervals: []am.TimeInterval{ti},
 		})
 	}
 
 	// Global first
 	for _, sw := range proj.SilenceWindows {
-		add("global", sw)
+		add(dsl.GlobalScope, sw)
 	}
 	// Teams next
 	for _, t := range proj.Teams {
 		for _, sw := range t.SilenceWindows {
-			add("team/"+t.Name, sw)
+			add(t.Name, sw)
 		}
 	}
 
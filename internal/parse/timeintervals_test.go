@@ -0,0 +1,126 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func window(name string, enabled bool) dsl.SilenceWindow {
+	return dsl.SilenceWindow{Name: name, Time: "22:00-23:00", Enabled: enabled}
+}
+
+func intervalNames(proj dsl.Project, opts parse.Options) []string {
+	sets, _ := parse.BuildTimeIntervals(proj, opts)
+	var names []string
+	for _, s := range sets {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestBuildTimeIntervals_Names(t *testing.T) {
+	tests := []struct {
+		name string
+		proj dsl.Project
+		opts parse.Options
+		want []string
+	}{
+		{
+			name: "teams reuse a window name",
+			proj: dsl.Project{Teams: []dsl.Team{
+				{Name: "payments", SilenceWindows: []dsl.SilenceWindow{window("nights", true)}},
+				{Name: "search", SilenceWindows: []dsl.SilenceWindow{window("nights", true)}},
+			}},
+			want: []string{"payments/nights", "search/nights"},
+		},
+		{
+			name: "team and global share a name",
+			proj: dsl.Project{
+				SilenceWindows: []dsl.SilenceWindow{window("nights", true)},
+				Teams: []dsl.Team{
+					{Name: "payments", SilenceWindows: []dsl.SilenceWindow{window("nights", true)}},
+				},
+			},
+			want: []string{"global/nights", "payments/nights"},
+		},
+		{
+			name: "duplicate within a team is rendered once",
+			proj: dsl.Project{Teams: []dsl.Team{
+				{Name: "payments", SilenceWindows: []dsl.SilenceWindow{window("nights", true), window("nights", true)}},
+			}},
+			want: []string{"payments/nights"},
+		},
+		{
+			name: "disabled windows are skipped",
+			proj: dsl.Project{Teams: []dsl.Team{
+				{Name: "payments", SilenceWindows: []dsl.SilenceWindow{window("nights", false)}},
+			}},
+		},
+		{
+			name: "receiver pattern applies",
+			proj: dsl.Project{Teams: []dsl.Team{
+				{Name: "payments", SilenceWindows: []dsl.SilenceWindow{window("nights", true)}},
+			}},
+			opts: parse.Options{ReceiverPattern: "{team}-{channel}"},
+			want: []string{"payments-nights"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, intervalNames(tt.proj, tt.opts))
+		})
+	}
+}
+
+func TestBuildFlowRoutes_SilenceWhenNames(t *testing.T) {
+	globals := []dsl.SilenceWindow{window("nights", true), window("weekends", true)}
+
+	tests := []struct {
+		name    string
+		windows []dsl.SilenceWindow
+		when    []string
+		want    []string
+	}{
+		{name: "global window", when: []string{"weekends"}, want: []string{"global/weekends"}},
+		{
+			name:    "team window wins over global",
+			windows: []dsl.SilenceWindow{window("nights", true)},
+			when:    []string{"nights"},
+			want:    []string{"payments/nights"},
+		},
+		{
+			name:    "disabled window is dropped",
+			windows: []dsl.SilenceWindow{window("lunch", false)},
+			when:    []string{"lunch", "weekends"},
+			want:    []string{"global/weekends"},
+		},
+		{name: "unknown name is kept", when: []string{"nope"}, want: []string{"nope"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj := dsl.Project{
+				SilenceWindows: globals,
+				Teams: []dsl.Team{{
+					Name:           "payments",
+					SilenceWindows: tt.windows,
+					Flows: []dsl.Flow{{
+						When:        []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+						Notify:      dsl.Targets{"slack"},
+						SilenceWhen: tt.when,
+					}},
+				}},
+			}
+
+			root, _ := parse.BuildFlowRoutes(proj, parse.Options{})
+			require.Len(t, root.Routes, 1)
+			require.Len(t, root.Routes[0].Routes, 1)
+			assert.Equal(t, tt.want, root.Routes[0].Routes[0].MuteTimeIntervals)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package routetest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText prints one line per test and, for failures, the labels and a
+// receiver diff, followed by a summary. It returns the number of failures.
+func WriteText(w io.Writer, results []Result) int {
+	failed := 0
+	for _, r := range results {
+		if r.Passed() {
+			fmt.Fprintf(w, "PASS %s: %s\n", r.Team, r.Name)
+			continue
+		}
+		failed++
+		fmt.Fprintf(w, "FAIL %s: %s\n", r.Team, r.Name)
+		for _, line := range r.details() {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	fmt.Fprintf(w, "\n%d passed, %d failed\n", len(results)-failed, failed)
+	return failed
+}
+
+// details explains a failure: the error, or the labels and receiver diff.
+func (r Result) details() []string {
+	if r.Err != nil {
+		return []string{"error: " + r.Err.Error()}
+	}
+	lines := []string{"labels: " + FormatLabels(r.Labels)}
+	return append(lines, r.Diff()...)
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the results as JUnit XML, one test suite per team.
+func WriteJUnit(w io.Writer, results []Result) error {
+	doc := junitSuites{Tests: len(results)}
+	index := map[string]int{}
+
+	for _, r := range results {
+		i, ok := index[r.Team]
+		if !ok {
+			i = len(doc.Suites)
+			index[r.Team] = i
+			doc.Suites = append(doc.Suites, junitSuite{Name: r.Team})
+		}
+		s := &doc.Suites[i]
+
+		c := junitCase{Name: r.Name, Classname: r.Team}
+		if !r.Passed() {
+			msg := "receivers differ"
+			if r.Err != nil {
+				msg = r.Err.Error()
+			}
+			c.Failure = &junitFailure{Message: msg, Text: strings.Join(r.details(), "\n")}
+			s.Failures++
+			doc.Failures++
+		}
+		s.Tests++
+		s.Cases = append(s.Cases, c)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,196 @@
+// Package routetest runs the routing expectations teams declare in
+// tests.yaml against a rendered Alertmanager config.
+package routetest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// Result is the outcome of one routing test.
+type Result struct {
+	Team     string
+	Name     string
+	Labels   map[string]string
+	At       time.Time
+	Expected []string // receiver names, sorted
+	Actual   []string // receivers notified, sorted
+	Matches  []am.RouteMatch
+	Muted    map[string]string // receiver -> interval that muted it
+	Err      error
+}
+
+// Passed reports whether the alert reached exactly the expected receivers.
+func (r Result) Passed() bool {
+	return r.Err == nil && equal(r.Expected, r.Actual)
+}
+
+// Run evaluates every team's tests against cfg. Tests without a timestamp
+// are evaluated at now; pattern is the receiver name pattern used to resolve
+// channel names in expectations.
+func Run(cfg am.Config, proj dsl.Project, pattern string, now time.Time) []Result {
+	receivers := map[string]struct{}{}
+	for _, r := range cfg.Receivers {
+		receivers[r.Name] = struct{}{}
+	}
+
+	var out []Result
+	for _, team := range proj.Teams {
+		for i, tc := range team.Tests {
+			res := Result{
+				Team:   team.Name,
+				Name:   tc.Name,
+				Labels: tc.Labels,
+				At:     now,
+				Muted:  map[string]string{},
+			}
+			if res.Name == "" {
+				res.Name = fmt.Sprintf("tests[%d]", i)
+			}
+			for _, e := range tc.Expect {
+				if _, ok := receivers[e]; !ok {
+					e = dsl.TargetReceiverName(pattern, team.Name, e)
+				}
+				res.Expected = append(res.Expected, e)
+			}
+			res.Expected = uniqueSorted(res.Expected)
+
+			if tc.At != "" {
+				at, err := time.Parse(time.RFC3339, tc.At)
+				if err != nil {
+					res.Err = fmt.Errorf("invalid at %q: expected RFC 3339, e.g. 2024-06-01T10:00:00Z", tc.At)
+					out = append(out, res)
+					continue
+				}
+				res.At = at
+			}
+
+			res.Err = evaluate(cfg, &res)
+			out = append(out, res)
+		}
+	}
+	return out
+}
+
+// evaluate dispatches the test alert and records who is notified.
+func evaluate(cfg am.Config, res *Result) error {
+	matches, err := cfg.Route.Match(res.Labels)
+	if err != nil {
+		return err
+	}
+	res.Matches = matches
+
+	var actual []string
+	for _, m := range matches {
+		muted, interval, err := cfg.Muted(m.Route, res.At)
+		if err != nil {
+			return fmt.Errorf("%s: %w", m.Path, err)
+		}
+		if muted {
+			res.Muted[m.Receiver] = interval
+			continue
+		}
+		actual = append(actual, m.Receiver)
+	}
+	res.Actual = uniqueSorted(actual)
+	return nil
+}
+
+// Diff returns the expected and actual receivers as diff lines: "-" for
+// expected receivers that were not notified, "+" for unexpected ones and " "
+// for receivers in both.
+func (r Result) Diff() []string {
+	exp := toSet(r.Expected)
+	act := toSet(r.Actual)
+
+	var lines []string
+	for _, name := range uniqueSorted(append(append([]string{}, r.Expected...), r.Actual...)) {
+		_, e := exp[name]
+		_, a := act[name]
+		switch {
+		case e && a:
+			lines = append(lines, "  "+name)
+		case e:
+			line := "- " + name
+			if interval, ok := r.Muted[name]; ok {
+				line += fmt.Sprintf(" (muted by %s)", interval)
+			}
+			lines = append(lines, line)
+		default:
+			lines = append(lines, "+ "+name+r.via(name))
+		}
+	}
+	return lines
+}
+
+// via names the routes that delivered to receiver, for the diff.
+func (r Result) via(receiver string) string {
+	var sources []string
+	for _, m := range r.Matches {
+		if m.Receiver != receiver {
+			continue
+		}
+		src := m.Route.Source
+		if src == "" {
+			src = m.Path
+		}
+		sources = append(sources, src)
+	}
+	if len(sources) == 0 {
+		return ""
+	}
+	return " (via " + strings.Join(uniqueSorted(sources), ", ") + ")"
+}
+
+// FormatLabels renders a label set as {a="1", b="2"}.
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%q", k, labels[k]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func uniqueSorted(in []string) []string {
+	if len(in) == 0 {
+		return nil
+	}
+	set := toSet(in)
+	out := make([]string, 0, len(set))
+	for s := range set {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func toSet(in []string) map[string]struct{} {
+	out := make(map[string]struct{}, len(in))
+	for _, s := range in {
+		out[s] = struct{}{}
+	}
+	return out
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package routetest_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/routetest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	cfg := am.Config{
+		Receivers: []am.Receiver{{Name: "default"}, {Name: "payments/slack"}, {Name: "payments/pager"}},
+		Route: am.Route{
+			Receiver: "default",
+			Routes: []am.Route{{
+				Matchers: []string{`team="payments"`},
+				Receiver: "payments/slack",
+				Source:   "team/payments",
+				Routes: []am.Route{{
+					Matchers:          []string{`severity="critical"`},
+					Receiver:          "payments/pager",
+					MuteTimeIntervals: []string{"nights"},
+					Source:            "team/payments flows[0]",
+				}},
+			}},
+		},
+		TimeIntervals: []am.TimeIntervalSet{{Name: "nights", TimeIntervals: []am.TimeInterval{{
+			Times: []struct {
+				Start string `yaml:"start_time"`
+				End   string `yaml:"end_time"`
+			}{{Start: "00:00", End: "06:00"}},
+		}}}},
+	}
+	proj := dsl.Project{Teams: []dsl.Team{{
+		Name: "payments",
+		Tests: []dsl.RouteTest{
+			{Name: "pages", Labels: map[string]string{"team": "payments", "severity": "critical"}, Expect: dsl.Targets{"pager"}},
+			{Name: "muted at night", Labels: map[string]string{"team": "payments", "severity": "critical"}, At: "2024-06-01T03:00:00Z"},
+			{Name: "receiver name", Labels: map[string]string{"team": "payments"}, Expect: dsl.Targets{"payments/slack"}},
+			{Name: "wrong", Labels: map[string]string{"team": "payments"}, Expect: dsl.Targets{"pager"}},
+			{Name: "expected but muted", Labels: map[string]string{"team": "payments", "severity": "critical"}, At: "2024-06-01T03:00:00Z", Expect: dsl.Targets{"pager"}},
+			{Labels: map[string]string{}, At: "yesterday"},
+		},
+	}}}
+
+	noon := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	results := routetest.Run(cfg, proj, "", noon)
+	require.Len(t, results, 6)
+
+	var passed []bool
+	for _, r := range results {
+		passed = append(passed, r.Passed())
+	}
+	assert.Equal(t, []bool{true, true, true, false, false, false}, passed)
+
+	assert.Equal(t, []string{"- payments/pager", "+ payments/slack (via team/payments)"}, results[3].Diff())
+	assert.Equal(t, []string{"- payments/pager (muted by nights)"}, results[4].Diff())
+	assert.Equal(t, "tests[5]", results[5].Name)
+	assert.Error(t, results[5].Err)
+}
+
+func TestReports(t *testing.T) {
+	results := []routetest.Result{
+		{Team: "payments", Name: "ok", Expected: []string{"a"}, Actual: []string{"a"}},
+		{Team: "payments", Name: "bad", Labels: map[string]string{"team": "payments"}, Expected: []string{"a"}, Actual: []string{"b"}},
+	}
+
+	var text bytes.Buffer
+	assert.Equal(t, 1, routetest.WriteText(&text, results))
+	assert.Contains(t, text.String(), "PASS payments: ok\n")
+	assert.Contains(t, text.String(), "FAIL payments: bad\n    labels: {team=\"payments\"}\n    - a\n    + b\n")
+	assert.Contains(t, text.String(), "1 passed, 1 failed")
+
+	var junit bytes.Buffer
+	require.NoError(t, routetest.WriteJUnit(&junit, results))
+	assert.Contains(t, junit.String(), `<testsuite name="payments" tests="2" failures="1">`)
+	assert.Contains(t, junit.String(), `<failure message="receivers differ">`)
+}
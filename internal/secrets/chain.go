@@ -0,0 +1,130 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ChainEntry is one provider in a ChainProvider together with its routing rule.
+// A key is only offered to the provider when it has Prefix (if set) and
+// matches Regex (if set). Entries without a rule accept every key.
+type ChainEntry struct {
+	Name     string
+	Provider Provider
+	Prefix   string
+	Regex    *regexp.Regexp
+	// StripPrefix removes Prefix from the key before it is passed to Provider,
+	// so ${VAULT_payments/slack} is looked up in Vault as "payments/slack".
+	StripPrefix bool
+}
+
+func (e ChainEntry) accepts(key string) bool {
+	if e.Prefix != "" && !strings.HasPrefix(key, e.Prefix) {
+		return false
+	}
+	if e.Regex != nil && !e.Regex.MatchString(key) {
+		return false
+	}
+	return true
+}
+
+// Resolution records which provider resolved a key.
+type Resolution struct {
+	Key      string
+	Provider string
+}
+
+// ChainProvider tries its entries in order. A provider returning ErrNotFound
+// falls through to the next matching entry; any other error stops the lookup.
+type ChainProvider struct {
+	entries []ChainEntry
+
+	mu       sync.Mutex
+	resolved map[string]string
+}
+
+// NewChainProvider returns a provider that consults entries in order.
+func NewChainProvider(entries []ChainEntry) (*ChainProvider, error) {
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("secrets chain has no providers")
+	}
+	for i, e := range entries {
+		if e.Provider == nil {
+			return nil, fmt.Errorf("secrets chain entry %d (%s) has no provider", i, e.Name)
+		}
+	}
+	return &ChainProvider{entries: entries, resolved: map[string]string{}}, nil
+}
+
+func (c *ChainProvider) Resolve(key string) (string, error) {
+	for _, e := range c.entries {
+		if !e.accepts(key) {
+			continue
+		}
+
+		lookup := key
+		if e.StripPrefix {
+			lookup = strings.TrimPrefix(key, e.Prefix)
+		}
+
+		v, err := e.Provider.Resolve(lookup)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", e.Name, err)
+		}
+
+		c.mu.Lock()
+		c.resolved[key] = e.Name
+		c.mu.Unlock()
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+// ResolveHint resolves key through the entries named provider, ignoring
+// their routing rules. It returns ErrNotFound when no such entry exists.
+func (c *ChainProvider) ResolveHint(provider, key string) (string, error) {
+	known := false
+	for _, e := range c.entries {
+		if e.Name != provider {
+			continue
+		}
+		known = true
+
+		v, err := e.Provider.Resolve(key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", e.Name, err)
+		}
+
+		c.mu.Lock()
+		c.resolved[provider+":"+key] = e.Name
+		c.mu.Unlock()
+		return v, nil
+	}
+	if !known {
+		return "", fmt.Errorf("no %q secrets provider configured: %w", provider, ErrNotFound)
+	}
+	return "", ErrNotFound
+}
+
+// Resolutions lists every key resolved so far and the provider that resolved it.
+func (c *ChainProvider) Resolutions() []Resolution {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]Resolution, 0, len(c.resolved))
+	for k, name := range c.resolved {
+		out = append(out, Resolution{Key: k, Provider: name})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
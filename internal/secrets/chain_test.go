@@ -0,0 +1,105 @@
+package secrets_test
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapProvider map[string]string
+
+func (m mapProvider) Resolve(key string) (string, error) {
+	if v, ok := m[key]; ok {
+		return v, nil
+	}
+	return "", secrets.ErrNotFound
+}
+
+type failingProvider struct{}
+
+func (failingProvider) Resolve(string) (string, error) { return "", errors.New("connection refused") }
+
+func TestChainProvider(t *testing.T) {
+	chain, err := secrets.NewChainProvider([]secrets.ChainEntry{
+		{
+			Name:        "vault",
+			Provider:    mapProvider{"payments/slack": "https://vault-slack"},
+			Prefix:      "VAULT_",
+			StripPrefix: true,
+		},
+		{
+			Name:     "ssm",
+			Provider: mapProvider{"SHARED_TOKEN": "from-ssm"},
+			Regex:    regexp.MustCompile(`^SHARED_`),
+		},
+		{
+			Name:     "env",
+			Provider: mapProvider{"CI_TOKEN": "from-env", "SHARED_TOKEN": "env-shadowed", "SHARED_OTHER": "env-fallback"},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr error
+	}{
+		{key: "VAULT_payments/slack", want: "https://vault-slack"},
+		{key: "SHARED_TOKEN", want: "from-ssm"},
+		{key: "SHARED_OTHER", want: "env-fallback"}, // ssm ErrNotFound falls through
+		{key: "CI_TOKEN", want: "from-env"},
+		{key: "VAULT_missing", wantErr: secrets.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := chain.Resolve(tt.key)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, []secrets.Resolution{
+		{Key: "CI_TOKEN", Provider: "env"},
+		{Key: "SHARED_OTHER", Provider: "env"},
+		{Key: "SHARED_TOKEN", Provider: "ssm"},
+		{Key: "VAULT_payments/slack", Provider: "vault"},
+	}, chain.Resolutions())
+}
+
+func TestChainProvider_ErrorStopsLookup(t *testing.T) {
+	chain, err := secrets.NewChainProvider([]secrets.ChainEntry{
+		{Name: "vault", Provider: failingProvider{}},
+		{Name: "env", Provider: mapProvider{"KEY": "v"}},
+	})
+	require.NoError(t, err)
+
+	_, err = chain.Resolve("KEY")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, secrets.ErrNotFound)
+	assert.Contains(t, err.Error(), "vault: connection refused")
+}
+
+func TestChainProvider_ResolveHint(t *testing.T) {
+	chain, err := secrets.NewChainProvider([]secrets.ChainEntry{
+		{Name: "vault", Provider: mapProvider{"secret/data/x#field": "from-vault"}, Prefix: "VAULT_"},
+		{Name: "env", Provider: mapProvider{"secret/data/x#field": "from-env"}},
+	})
+	require.NoError(t, err)
+
+	// the hint bypasses the prefix rule and picks the named provider
+	out, missing, err := secrets.InterpolateString("${vault:secret/data/x#field}", chain)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+	assert.Equal(t, "from-vault", out)
+
+	_, err = chain.ResolveHint("ssm", "anything")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+}
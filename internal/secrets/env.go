@@ -11,3 +11,5 @@ func (p *EnvProvider) Resolve(key string) (string, error) {
 	}
 	return "", ErrNotFound
 }
+
+func (p *EnvProvider) Name() string { return "env" }
@@ -1,42 +1,75 @@
 package secrets
 
 import (
+	"errors"
 	"fmt"
-	"regexp"
+	"sort"
 	"strings"
 )
 
-// Placeholder format: ${VAR_NAME}
-// Allowed characters: letters, digits, underscore.
-var placeholderRe = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
-
-// InterpolateString replaces ${VAR} placeholders using the given Provider.
+// InterpolateString replaces placeholders (see Placeholder) using the given Provider.
 // It returns the interpolated string, a slice of missing variable names,
-// and an error only for non-recoverable issues (regex, provider failure).
+// and an error only for non-recoverable issues (provider failure).
 // Missing variables DO NOT produce an error; they are collected and left intact
 // so the caller can decide whether to warn or fail (e.g., --strict).
 func InterpolateString(s string, p Provider) (string, []string, error) {
+	out, missing, err := interpolate(s, p)
+	keys := make([]string, 0, len(missing))
+	for _, ph := range missing {
+		keys = append(keys, ph.Key)
+	}
+	return out, keys, err
+}
+
+// interpolate resolves every placeholder in s and returns the unresolved ones.
+func interpolate(s string, p Provider) (string, []Placeholder, error) {
 	if s == "" {
 		return s, nil, nil
 	}
-	missing := []string{}
+	var (
+		missing []Placeholder
+		provErr error
+	)
 
-	out := placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
-		key := placeholderRe.FindStringSubmatch(m)[1]
-		val, err := p.Resolve(key)
+	out := scanPlaceholders(s, func(ph Placeholder) string {
+		val, err := resolvePlaceholder(p, ph)
+		if err == nil && val == "" && (ph.HasDefault || ph.Required) {
+			// like the shell, ":-" and ":?" treat an empty value as unset
+			err = ErrNotFound
+		}
 		if err == nil {
 			return val
 		}
-		if err == ErrNotFound {
-			missing = append(missing, key)
-			return m // keep placeholder intact
+		if errors.Is(err, ErrNotFound) {
+			if ph.HasDefault {
+				return ph.Default
+			}
+			missing = append(missing, ph)
+			return ph.Raw // keep placeholder intact
 		}
-		// Other provider errors: keep placeholder and bubble up via caller (we track via a marker)
-		missing = append(missing, key)
-		return m
+		// Other provider errors: keep placeholder and report the first failure
+		missing = append(missing, ph)
+		if provErr == nil {
+			provErr = fmt.Errorf("resolve %q: %w", ph.Key, err)
+		}
+		return ph.Raw
 	})
 
-	return out, missing, nil
+	return out, missing, provErr
+}
+
+// resolvePlaceholder resolves ph, honouring a provider hint when present.
+func resolvePlaceholder(p Provider, ph Placeholder) (string, error) {
+	if ph.Provider == "" {
+		return p.Resolve(ph.Key)
+	}
+	if hr, ok := p.(HintResolver); ok {
+		return hr.ResolveHint(ph.Provider, ph.Key)
+	}
+	if n, ok := p.(Named); ok && n.Name() == ph.Provider {
+		return p.Resolve(ph.Key)
+	}
+	return "", fmt.Errorf("no %q secrets provider configured: %w", ph.Provider, ErrNotFound)
 }
 
 // InterpolateMapString applies InterpolateString to every value in a map[string]string.
@@ -81,24 +114,48 @@ func dedup(ss []string) []string {
 	return out
 }
 
-// HasPlaceholders returns true if the string contains any ${VAR} placeholders.
+// HasPlaceholders returns true if the string contains any placeholders.
 func HasPlaceholders(s string) bool {
-	return placeholderRe.MatchString(s)
+	found := false
+	scanPlaceholders(s, func(ph Placeholder) string {
+		found = true
+		return ph.Raw
+	})
+	return found
 }
 
-// ListPlaceholders returns unique placeholder keys contained in s.
-func ListPlaceholders(s string) []string {
-	matches := placeholderRe.FindAllStringSubmatch(s, -1)
-	if len(matches) == 0 {
-		return nil
-	}
-	keys := make([]string, 0, len(matches))
-	for _, m := range matches {
-		if len(m) > 1 {
-			keys = append(keys, m[1])
+// LiteralParts returns the text of s that does not come from a provider: the
+// literal runs around placeholders and the non-empty :- defaults, each in
+// order of appearance. Without placeholders, literals is s itself.
+func LiteralParts(s string) (literals, defaults []string) {
+	const cut = "\x00"
+	out := scanPlaceholders(s, func(ph Placeholder) string {
+		if ph.HasDefault && ph.Default != "" {
+			defaults = append(defaults, ph.Default)
+		}
+		return cut
+	})
+	for _, part := range strings.Split(out, cut) {
+		if part != "" {
+			literals = append(literals, part)
 		}
 	}
-	return dedup(keys)
+	return literals, defaults
+}
+
+// ListPlaceholders returns the unique placeholders contained in s.
+// Escaped placeholders ($${...}) are not reported.
+func ListPlaceholders(s string) []Placeholder {
+	var out []Placeholder
+	seen := map[string]struct{}{}
+	scanPlaceholders(s, func(ph Placeholder) string {
+		if _, ok := seen[ph.Raw]; !ok {
+			seen[ph.Raw] = struct{}{}
+			out = append(out, ph)
+		}
+		return ph.Raw
+	})
+	return out
 }
 
 // InterpolateSlice applies InterpolateString to each item in a slice.
@@ -121,6 +178,83 @@ func InterpolateSlice(in []string, p Provider) ([]string, []string, error) {
 	return out, dedup(missing), nil
 }
 
+// Unresolved is a placeholder that could not be resolved, together with the
+// field path it was found at (e.g. "configs[0].http_config.bearer_token").
+type Unresolved struct {
+	Field string
+	Key   string
+	// Required and Message come from the ${KEY:?message} form.
+	Required bool
+	Message  string
+}
+
+// InterpolateValue resolves placeholders in every string reachable from v,
+// descending into nested maps and lists. The input is not modified; a copy
+// with resolved values is returned. field is the path of v and is used as the
+// prefix for the Field of every Unresolved entry.
+func InterpolateValue(v any, field string, p Provider) (any, []Unresolved, error) {
+	switch t := v.(type) {
+	case string:
+		iv, miss, err := interpolate(t, p)
+		var unresolved []Unresolved
+		seen := map[string]struct{}{}
+		for _, ph := range miss {
+			if _, ok := seen[ph.Raw]; ok {
+				continue
+			}
+			seen[ph.Raw] = struct{}{}
+			unresolved = append(unresolved, Unresolved{
+				Field:    field,
+				Key:      ph.Key,
+				Required: ph.Required,
+				Message:  ph.Message,
+			})
+		}
+		return iv, unresolved, err
+
+	case map[string]any:
+		out := make(map[string]any, len(t))
+		var unresolved []Unresolved
+
+		// Walk keys in order so diagnostics are stable.
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			child := k
+			if field != "" {
+				child = field + "." + k
+			}
+			iv, miss, err := InterpolateValue(t[k], child, p)
+			unresolved = append(unresolved, miss...)
+			if err != nil {
+				return v, unresolved, err
+			}
+			out[k] = iv
+		}
+		return out, unresolved, nil
+
+	case []any:
+		out := make([]any, len(t))
+		var unresolved []Unresolved
+		for i, item := range t {
+			iv, miss, err := InterpolateValue(item, fmt.Sprintf("%s[%d]", field, i), p)
+			unresolved = append(unresolved, miss...)
+			if err != nil {
+				return v, unresolved, err
+			}
+			out[i] = iv
+		}
+		return out, unresolved, nil
+
+	default:
+		return v, nil, nil
+	}
+}
+
 // InterpolateStructFields is a helper to interpolate known common fields often used in channels.
 // You can expand this as needed in parse layer.
 type ChannelLike struct {
@@ -180,10 +314,10 @@ func interpolateField(val string, acc []string, p Provider) (string, []string, e
 	return iv, append(acc, miss...), nil
 }
 
-// Redact replaces any ${VAR} pattern with "***" without resolving it.
+// Redact replaces any placeholder with "***" without resolving it.
 // Useful for logs or --redact in build step.
 func Redact(s string) string {
-	return placeholderRe.ReplaceAllString(s, "***")
+	return scanPlaceholders(s, func(Placeholder) string { return "***" })
 }
 
 // RedactMap applies Redact to all values.
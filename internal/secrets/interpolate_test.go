@@ -0,0 +1,124 @@
+package secrets_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateValue(t *testing.T) {
+	t.Setenv("SLACK_WEBHOOK", "https://hooks.slack.com/services/T/B/x")
+	t.Setenv("BEARER", "s3cr3t")
+
+	in := map[string]any{
+		"api_url": "${SLACK_WEBHOOK}",
+		"channel": "#payments",
+		"http_config": map[string]any{
+			"authorization": map[string]any{
+				"credentials": "${BEARER}",
+			},
+		},
+		"fields": []any{
+			map[string]any{"title": "region", "value": "${MISSING_REGION}"},
+		},
+		"send_resolved": true,
+	}
+
+	out, unresolved, err := secrets.InterpolateValue(in, "configs[0]", &secrets.EnvProvider{})
+	require.NoError(t, err)
+
+	m := out.(map[string]any)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/x", m["api_url"])
+	assert.Equal(t, "s3cr3t", m["http_config"].(map[string]any)["authorization"].(map[string]any)["credentials"])
+	assert.Equal(t, "${MISSING_REGION}", m["fields"].([]any)[0].(map[string]any)["value"])
+	assert.Equal(t, true, m["send_resolved"])
+
+	assert.Equal(t, []secrets.Unresolved{
+		{Field: "configs[0].fields[0].value", Key: "MISSING_REGION"},
+	}, unresolved)
+
+	// input must be left untouched
+	assert.Equal(t, "${SLACK_WEBHOOK}", in["api_url"])
+}
+
+func TestInterpolateString_Syntax(t *testing.T) {
+	t.Setenv("PRESENT", "value")
+	t.Setenv("EMPTY", "")
+
+	p := &secrets.EnvProvider{}
+
+	tests := []struct {
+		name        string
+		in          string
+		want        string
+		wantMissing []string
+	}{
+		{name: "plain", in: "x=${PRESENT}", want: "x=value"},
+		{name: "default unused", in: "${PRESENT:-fallback}", want: "value"},
+		{name: "default used", in: "${ABSENT:-fallback}", want: "fallback"},
+		{name: "empty default", in: "[${ABSENT:-}]", want: "[]"},
+		{name: "empty uses default", in: "[${EMPTY:-fallback}]", want: "[fallback]"},
+		{name: "empty plain", in: "[${EMPTY}]", want: "[]"},
+		{name: "required empty", in: "${EMPTY:?must not be empty}", want: "${EMPTY:?must not be empty}", wantMissing: []string{"EMPTY"}},
+		{name: "required present", in: "${PRESENT:?set PRESENT}", want: "value"},
+		{name: "required missing", in: "${ABSENT:?set ABSENT}", want: "${ABSENT:?set ABSENT}", wantMissing: []string{"ABSENT"}},
+		{name: "escaped", in: "literal $${PRESENT} and ${PRESENT}", want: "literal ${PRESENT} and value"},
+		{name: "template braces untouched", in: `{{ template "slack.title" . }} ${not a key}`, want: `{{ template "slack.title" . }} ${not a key}`},
+		{name: "hint for env", in: "${env:PRESENT}", want: "value"},
+		{name: "hint for unconfigured provider", in: "${vault:secret/data/x#field}", want: "${vault:secret/data/x#field}", wantMissing: []string{"secret/data/x#field"}},
+		{name: "missing", in: "${ABSENT}", want: "${ABSENT}", wantMissing: []string{"ABSENT"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missing, err := secrets.InterpolateString(tt.in, p)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			if tt.wantMissing == nil {
+				assert.Empty(t, missing)
+			} else {
+				assert.Equal(t, tt.wantMissing, missing)
+			}
+		})
+	}
+}
+
+func TestListPlaceholders(t *testing.T) {
+	got := secrets.ListPlaceholders("${A} ${A} ${B:-x} ${C:?need C} ${vault:secret/data/x#f} $${ESCAPED}")
+	assert.Equal(t, []secrets.Placeholder{
+		{Raw: "${A}", Key: "A"},
+		{Raw: "${B:-x}", Key: "B", Default: "x", HasDefault: true},
+		{Raw: "${C:?need C}", Key: "C", Required: true, Message: "need C"},
+		{Raw: "${vault:secret/data/x#f}", Key: "secret/data/x#f", Provider: "vault"},
+	}, got)
+
+	assert.True(t, secrets.HasPlaceholders("${A}"))
+	assert.False(t, secrets.HasPlaceholders("$${A}"))
+	assert.Equal(t, "token=*** literal=${A}", secrets.Redact("token=${A:-x} literal=$${A}"))
+}
+
+func TestLiteralParts(t *testing.T) {
+	tests := []struct {
+		in           string
+		wantLiterals []string
+		wantDefaults []string
+	}{
+		{in: "plain", wantLiterals: []string{"plain"}},
+		{in: "${TOKEN}"},
+		{in: "${TOKEN:-}"},
+		{in: "${URL:-https://example.com/x}", wantDefaults: []string{"https://example.com/x"}},
+		{in: "${BASE}/hook/abc", wantLiterals: []string{"/hook/abc"}},
+		{in: "a${X}b${Y:-c}", wantLiterals: []string{"a", "b"}, wantDefaults: []string{"c"}},
+		{in: "$${ESCAPED}", wantLiterals: []string{"${ESCAPED}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			literals, defaults := secrets.LiteralParts(tt.in)
+			assert.Equal(t, tt.wantLiterals, literals)
+			assert.Equal(t, tt.wantDefaults, defaults)
+		})
+	}
+}
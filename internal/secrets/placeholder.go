@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Placeholder is a parsed ${...} reference. Supported forms:
+//
+//	${NAME}                     resolve NAME
+//	${NAME:-default}            use "default" when NAME is not found or empty
+//	${NAME:?error message}      NAME is required; report the message when missing or empty
+//	${vault:secret/data/x#f}    resolve the key through the "vault" provider
+//	$${NAME}                    escape: emits a literal ${NAME}
+//
+// Keys may contain letters, digits, "_", "/", ".", "-" and "#" so that
+// path-style keys such as ${payments/slack_webhook} work.
+type Placeholder struct {
+	// Raw is the placeholder as written, e.g. "${NAME:-fallback}".
+	Raw string `json:"raw"`
+	// Key is the secret key to resolve.
+	Key string `json:"key"`
+	// Provider is the optional provider hint ("vault" in ${vault:...}).
+	Provider string `json:"provider,omitempty"`
+	// Default is used when HasDefault is set and the key is not found.
+	Default    string `json:"default,omitempty"`
+	HasDefault bool   `json:"has_default,omitempty"`
+	// Required marks ${NAME:?message}; Message explains what is missing.
+	Required bool   `json:"required,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+var (
+	keyRe  = regexp.MustCompile(`^[A-Za-z0-9_./#-]+$`)
+	hintRe = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9_-]*):(.+)$`)
+)
+
+// parsePlaceholder parses the body between "${" and "}".
+func parsePlaceholder(body string) (Placeholder, bool) {
+	ph := Placeholder{Raw: "${" + body + "}"}
+
+	// Provider hint: "name:rest", unless the colon starts an operator.
+	if m := hintRe.FindStringSubmatch(body); m != nil && m[2][0] != '-' && m[2][0] != '?' {
+		ph.Provider = m[1]
+		body = m[2]
+	}
+
+	key := body
+	if i := strings.Index(body, ":-"); i >= 0 {
+		key = body[:i]
+		ph.Default = body[i+2:]
+		ph.HasDefault = true
+	} else if i := strings.Index(body, ":?"); i >= 0 {
+		key = body[:i]
+		ph.Required = true
+		ph.Message = strings.TrimSpace(body[i+2:])
+	}
+
+	if !keyRe.MatchString(key) {
+		return Placeholder{}, false
+	}
+	ph.Key = key
+	return ph, true
+}
+
+// scanPlaceholders walks s and calls replace for every placeholder, writing
+// its return value in place of the placeholder. Escaped placeholders ($${...})
+// are emitted as literal ${...}; text that does not form a valid placeholder
+// is copied unchanged.
+func scanPlaceholders(s string, replace func(Placeholder) string) string {
+	if !strings.Contains(s, "${") {
+		return s
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if strings.HasPrefix(s[i:], "$${") {
+			b.WriteString("${")
+			i += 3
+			continue
+		}
+		if strings.HasPrefix(s[i:], "${") {
+			if end := strings.IndexByte(s[i+2:], '}'); end >= 0 {
+				if ph, ok := parsePlaceholder(s[i+2 : i+2+end]); ok {
+					b.WriteString(replace(ph))
+					i += 2 + end + 1
+					continue
+				}
+			}
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
@@ -12,19 +12,32 @@ type Provider interface {
 	Resolve(key string) (string, error)
 }
 
+// Named is implemented by providers that can be addressed by a hint,
+// as in ${vault:payments/slack_webhook}.
+type Named interface {
+	Name() string
+}
+
+// HintResolver is implemented by providers that route a hinted key to one
+// of several backends (see ChainProvider).
+type HintResolver interface {
+	ResolveHint(provider, key string) (string, error)
+}
+
 // NewProvider creates a Provider based on name and an optional config path.
-// Supported names (MVP): "env"
-// Stubs for later: "sops", "vault", "ssm"
+// Supported names: "env", "sops" (configPath is the encrypted file),
+// "vault" (configPath is a VaultConfig file) and "ssm" (configPath is an
+// SSMConfig file).
 func NewProvider(name, configPath string) (Provider, error) {
 	switch name {
 	case "", "env":
 		return &EnvProvider{}, nil
 	case "sops":
-		return nil, fmt.Errorf("secrets provider %q not implemented yet", name)
+		return NewSopsProvider(configPath)
 	case "vault":
-		return nil, fmt.Errorf("secrets provider %q not implemented yet", name)
+		return NewVaultProvider(configPath)
 	case "ssm":
-		return nil, fmt.Errorf("secrets provider %q not implemented yet", name)
+		return NewSSMProvider(configPath)
 	default:
 		return nil, fmt.Errorf("unknown secrets provider %q", name)
 	}
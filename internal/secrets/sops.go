@@ -0,0 +1,173 @@
+package secrets
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultSopsBinary is used when FUSE_SOPS_BINARY is not set.
+const DefaultSopsBinary = "sops"
+
+// SopsProvider resolves secrets from a SOPS-encrypted YAML or JSON file.
+//
+// Decryption is delegated to the sops binary, so age, PGP and KMS keys are
+// picked up the same way sops does it (SOPS_AGE_KEY_FILE, GNUPGHOME, ...).
+// Nested keys are flattened with "_" so that
+//
+//	payments:
+//	  slack_webhook: https://...
+//
+// is available as ${payments_slack_webhook}. Lookups fall back to a
+// case-insensitive match, so ${PAYMENTS_SLACK_WEBHOOK} works as well. A file
+// in which two keys flatten or fold to the same name is rejected, as either
+// value could be meant.
+type SopsProvider struct {
+	File   string
+	Binary string
+
+	once   sync.Once
+	values map[string]string
+	folded map[string]string
+	err    error
+}
+
+// NewSopsProvider returns a provider for the encrypted file at path.
+// The sops binary can be overridden with FUSE_SOPS_BINARY.
+func NewSopsProvider(path string) (*SopsProvider, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, fmt.Errorf("sops provider requires --secrets-config pointing at an encrypted file")
+	}
+	bin := os.Getenv("FUSE_SOPS_BINARY")
+	if bin == "" {
+		bin = DefaultSopsBinary
+	}
+	return &SopsProvider{File: path, Binary: bin}, nil
+}
+
+func (p *SopsProvider) Name() string { return "sops" }
+
+func (p *SopsProvider) Resolve(key string) (string, error) {
+	p.once.Do(p.load)
+	if p.err != nil {
+		return "", p.err
+	}
+	if v, ok := p.values[key]; ok {
+		return v, nil
+	}
+	if v, ok := p.folded[strings.ToLower(key)]; ok {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+// Keys returns the flattened keys available in the decrypted file.
+func (p *SopsProvider) Keys() ([]string, error) {
+	p.once.Do(p.load)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return sortedKeys(p.values), nil
+}
+
+// load decrypts the file once per run.
+func (p *SopsProvider) load() {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(p.Binary, "--decrypt", p.File)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		p.err = fmt.Errorf("sops decrypt %s: %s", p.File, msg)
+		return
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(stdout.Bytes(), &doc); err != nil {
+		p.err = fmt.Errorf("sops decrypt %s: parse output: %w", p.File, err)
+		return
+	}
+
+	// The sops metadata block is only present when the file was not decrypted.
+	delete(doc, "sops")
+
+	p.values = map[string]string{}
+	sources := map[string]string{}
+	if err := flatten("", "", doc, p.values, sources); err != nil {
+		p.err = fmt.Errorf("sops decrypt %s: %w", p.File, err)
+		return
+	}
+
+	p.folded = make(map[string]string, len(p.values))
+	foldedFrom := make(map[string]string, len(p.values))
+	for _, k := range sortedKeys(p.values) {
+		lower := strings.ToLower(k)
+		if prev, ok := foldedFrom[lower]; ok {
+			p.err = fmt.Errorf("sops decrypt %s: keys %s and %s both resolve as ${%s} case-insensitively", p.File, sources[prev], sources[k], lower)
+			return
+		}
+		foldedFrom[lower] = k
+		p.folded[lower] = p.values[k]
+	}
+}
+
+// flatten writes every scalar leaf of v into out, joining nested keys with
+// "_". sources records the path each flattened key came from, e.g. a.b, and
+// two paths flattening to the same key are an error.
+func flatten(prefix, path string, v any, out, sources map[string]string) error {
+	join := func(k, sep string) (string, string) {
+		if prefix == "" {
+			return k, k
+		}
+		return prefix + "_" + k, path + sep + k
+	}
+
+	switch t := v.(type) {
+	case map[string]any:
+		for _, k := range sortedKeys(t) {
+			key, p := join(k, ".")
+			if err := flatten(key, p, t[k], out, sources); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []any:
+		for i, child := range t {
+			key, _ := join(fmt.Sprint(i), "")
+			if err := flatten(key, fmt.Sprintf("%s[%d]", path, i), child, out, sources); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if prev, ok := sources[prefix]; ok {
+		return fmt.Errorf("keys %s and %s both flatten to ${%s}", prev, path, prefix)
+	}
+	sources[prefix] = path
+	if v == nil {
+		out[prefix] = ""
+	} else {
+		out[prefix] = fmt.Sprint(v)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
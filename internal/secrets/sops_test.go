@@ -0,0 +1,125 @@
+package secrets_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSops writes a shell script that behaves like `sops --decrypt <file>` by
+// printing the (plain text) fixture it is given.
+func fakeSops(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake sops binary requires a POSIX shell")
+	}
+	bin := filepath.Join(t.TempDir(), "sops")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0o755))
+	return bin
+}
+
+func TestSopsProvider(t *testing.T) {
+	fixture := filepath.Join(t.TempDir(), "secrets.enc.yaml")
+	require.NoError(t, os.WriteFile(fixture, []byte(`
+SLACK_WEBHOOK: https://hooks.slack.com/services/T/B/x
+payments:
+  opsgenie_key: abc123
+  retries: 3
+`), 0o600))
+
+	t.Setenv("FUSE_SOPS_BINARY", fakeSops(t, `[ "$1" = "--decrypt" ] || exit 2
+cat "$2"
+`))
+
+	p, err := secrets.NewProvider("sops", fixture)
+	require.NoError(t, err)
+
+	tests := []struct {
+		key     string
+		want    string
+		wantErr error
+	}{
+		{key: "SLACK_WEBHOOK", want: "https://hooks.slack.com/services/T/B/x"},
+		{key: "payments_opsgenie_key", want: "abc123"},
+		{key: "PAYMENTS_OPSGENIE_KEY", want: "abc123"},
+		{key: "payments_retries", want: "3"},
+		{key: "NOPE", wantErr: secrets.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, err := p.Resolve(tt.key)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSopsProvider_DecryptFailure(t *testing.T) {
+	t.Setenv("FUSE_SOPS_BINARY", fakeSops(t, `echo "Failed to get the data key required to decrypt the SOPS file." >&2
+exit 128
+`))
+
+	p, err := secrets.NewProvider("sops", "secrets.enc.yaml")
+	require.NoError(t, err)
+
+	_, err = p.Resolve("SLACK_WEBHOOK")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, secrets.ErrNotFound)
+	assert.Contains(t, err.Error(), "Failed to get the data key")
+}
+
+func TestSopsProvider_KeyCollisions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "nested and flat key",
+			content: "a:\n  b: x\na_b: y\n",
+			wantErr: "keys a.b and a_b both flatten to ${a_b}",
+		},
+		{
+			name:    "list index and flat key",
+			content: "hosts: [x]\nhosts_0: y\n",
+			wantErr: "keys hosts[0] and hosts_0 both flatten to ${hosts_0}",
+		},
+		{
+			name:    "keys differing in case",
+			content: "TOKEN: x\ntoken: y\n",
+			wantErr: "keys TOKEN and token both resolve as ${token} case-insensitively",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fixture := filepath.Join(t.TempDir(), "secrets.enc.yaml")
+			require.NoError(t, os.WriteFile(fixture, []byte(tt.content), 0o600))
+			t.Setenv("FUSE_SOPS_BINARY", fakeSops(t, `cat "$2"
+`))
+
+			p, err := secrets.NewProvider("sops", fixture)
+			require.NoError(t, err)
+
+			_, err = p.Resolve("token")
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, secrets.ErrNotFound)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSopsProvider_RequiresFile(t *testing.T) {
+	_, err := secrets.NewProvider("sops", "")
+	assert.Error(t, err)
+}
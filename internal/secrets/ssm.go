@@ -0,0 +1,218 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"gopkg.in/yaml.v3"
+)
+
+// SSMConfig configures the AWS SSM Parameter Store provider. It is read from
+// the file passed with --secrets-config.
+type SSMConfig struct {
+	// Path is the parameter path prefix, e.g. "/fuse/prod".
+	Path string `yaml:"path"`
+	// Region defaults to the AWS SDK's resolution: AWS_REGION, then the
+	// profile's region.
+	Region string `yaml:"region"`
+	// Endpoint overrides the SSM endpoint (e.g. a local stand-in); defaults
+	// to AWS_ENDPOINT_URL_SSM, then the regional endpoint.
+	Endpoint string `yaml:"endpoint"`
+	// Profile in the shared AWS config and credentials files; defaults to
+	// AWS_PROFILE, then "default".
+	Profile string `yaml:"profile"`
+	// Recursive fetches parameters below nested paths too (default true).
+	Recursive *bool `yaml:"recursive"`
+	// Types lists the parameter types to expose (default [SecureString]);
+	// add String to read plain parameters too.
+	Types []string `yaml:"types"`
+	// MaxRetries on throttling, 5xx and transient network errors (default 5;
+	// 0 disables retries).
+	MaxRetries *int `yaml:"max_retries"`
+	// RetryMaxDelay caps the jittered exponential backoff between retries
+	// (default 20s).
+	RetryMaxDelay time.Duration `yaml:"retry_max_delay"`
+	// Timeout for each HTTP request (default 10s).
+	Timeout time.Duration `yaml:"timeout"`
+
+	// Static credentials; when unset the AWS SDK's default credential chain
+	// is used (environment, shared config and credentials files, SSO, web
+	// identity, container and instance credentials).
+	AccessKeyID     string `yaml:"access_key_id"`
+	SecretAccessKey string `yaml:"secret_access_key"`
+	SessionToken    string `yaml:"session_token"`
+}
+
+// SSMProvider resolves placeholders from SecureString parameters stored under
+// a path prefix. All parameters below the prefix are fetched with
+// GetParametersByPath on first use and cached for the run.
+//
+// With path "/fuse/prod", the parameter "/fuse/prod/payments/slack_webhook"
+// is available as ${payments/slack_webhook} and by its full name.
+type SSMProvider struct {
+	cfg SSMConfig
+
+	once   sync.Once
+	params map[string]string
+	err    error
+}
+
+// NewSSMProvider reads an SSMConfig from configPath and returns a provider.
+func NewSSMProvider(configPath string) (*SSMProvider, error) {
+	var cfg SSMConfig
+	if strings.TrimSpace(configPath) != "" {
+		b, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+		}
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+	}
+	return NewSSMProviderFromConfig(cfg)
+}
+
+// NewSSMProviderFromConfig applies defaults to cfg and returns a provider.
+// The AWS configuration is only loaded on first use so that projects without
+// SSM placeholders do not need AWS access.
+func NewSSMProviderFromConfig(cfg SSMConfig) (*SSMProvider, error) {
+	if strings.TrimSpace(cfg.Path) == "" {
+		return nil, fmt.Errorf("ssm provider: 'path' is required")
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		cfg.Path = "/" + cfg.Path
+	}
+	if cfg.Recursive == nil {
+		recursive := true
+		cfg.Recursive = &recursive
+	}
+	if len(cfg.Types) == 0 {
+		cfg.Types = []string{"SecureString"}
+	}
+	if cfg.MaxRetries == nil {
+		retries := 5
+		cfg.MaxRetries = &retries
+	}
+	if *cfg.MaxRetries < 0 {
+		return nil, fmt.Errorf("ssm provider: max_retries must not be negative")
+	}
+	if cfg.RetryMaxDelay == 0 {
+		cfg.RetryMaxDelay = 20 * time.Second
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	return &SSMProvider{cfg: cfg}, nil
+}
+
+func (p *SSMProvider) Name() string { return "ssm" }
+
+func (p *SSMProvider) Resolve(key string) (string, error) {
+	p.once.Do(p.load)
+	if p.err != nil {
+		return "", p.err
+	}
+	if v, ok := p.params[key]; ok {
+		return v, nil
+	}
+	return "", ErrNotFound
+}
+
+// load pages through GetParametersByPath and indexes every parameter of the
+// configured types by its full name and by its name relative to the path.
+func (p *SSMProvider) load() {
+	ctx := context.Background()
+
+	client, err := p.client(ctx)
+	if err != nil {
+		p.err = err
+		return
+	}
+
+	p.params = map[string]string{}
+	prefix := strings.TrimRight(p.cfg.Path, "/") + "/"
+
+	pages := ssm.NewGetParametersByPathPaginator(client, &ssm.GetParametersByPathInput{
+		Path:           aws.String(p.cfg.Path),
+		Recursive:      p.cfg.Recursive,
+		WithDecryption: aws.Bool(true),
+	})
+	for pages.HasMorePages() {
+		out, err := pages.NextPage(ctx)
+		if err != nil {
+			p.err = fmt.Errorf("ssm provider: %w", err)
+			return
+		}
+		for _, param := range out.Parameters {
+			if !p.exposes(string(param.Type)) {
+				continue
+			}
+			name, value := aws.ToString(param.Name), aws.ToString(param.Value)
+			p.params[name] = value
+			if rel := strings.TrimPrefix(name, prefix); rel != name {
+				p.params[rel] = value
+			}
+		}
+	}
+}
+
+// client loads the AWS configuration the way the AWS CLI and SDKs do and
+// returns an SSM client for it.
+func (p *SSMProvider) client(ctx context.Context) (*ssm.Client, error) {
+	attempts := *p.cfg.MaxRetries + 1
+	opts := []func(*awsconfig.LoadOptions) error{
+		awsconfig.WithHTTPClient(awshttp.NewBuildableClient().WithTimeout(p.cfg.Timeout)),
+		awsconfig.WithRetryer(func() aws.Retryer {
+			return retry.NewStandard(func(o *retry.StandardOptions) {
+				o.MaxAttempts = attempts
+				o.MaxBackoff = p.cfg.RetryMaxDelay
+			})
+		}),
+	}
+	if p.cfg.Region != "" {
+		opts = append(opts, awsconfig.WithRegion(p.cfg.Region))
+	}
+	if p.cfg.Profile != "" {
+		opts = append(opts, awsconfig.WithSharedConfigProfile(p.cfg.Profile))
+	}
+	if p.cfg.AccessKeyID != "" {
+		opts = append(opts, awsconfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(p.cfg.AccessKeyID, p.cfg.SecretAccessKey, p.cfg.SessionToken),
+		))
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("ssm provider: load AWS config: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("ssm provider: region is required (config 'region', AWS_REGION or the profile's region)")
+	}
+
+	return ssm.NewFromConfig(cfg, func(o *ssm.Options) {
+		if p.cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(p.cfg.Endpoint)
+		}
+	}), nil
+}
+
+// exposes reports whether parameters of type t are resolved.
+func (p *SSMProvider) exposes(t string) bool {
+	for _, want := range p.cfg.Types {
+		if strings.EqualFold(want, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package secrets_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSSM stands in for the SSM JSON API. It pages results two at a time and
+// throttles the first `throttle` requests.
+type fakeSSM struct {
+	t        *testing.T
+	params   []map[string]string
+	throttle int
+	calls    int
+}
+
+func (f *fakeSSM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls++
+
+	assert.Equal(f.t, "AmazonSSM.GetParametersByPath", r.Header.Get("X-Amz-Target"))
+	assert.True(f.t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+	assert.Contains(f.t, r.Header.Get("Authorization"), "/us-east-1/ssm/aws4_request")
+
+	if f.throttle > 0 {
+		f.throttle--
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "com.amazonaws.ssm#ThrottlingException",
+			"message": "Rate exceeded",
+		})
+		return
+	}
+
+	var req struct {
+		Path           string
+		WithDecryption bool
+		NextToken      string
+	}
+	require.NoError(f.t, json.NewDecoder(r.Body).Decode(&req))
+	assert.Equal(f.t, "/fuse/prod", req.Path)
+	assert.True(f.t, req.WithDecryption)
+
+	start := 0
+	if req.NextToken != "" {
+		start = 2
+	}
+	end := min(start+2, len(f.params))
+
+	resp := map[string]any{"Parameters": f.params[start:end]}
+	if end < len(f.params) {
+		resp["NextToken"] = "page-2"
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// ssmConfig writes a provider config pointing at endpoint with static
+// credentials, so the tests do not depend on the host's AWS setup.
+func ssmConfig(t *testing.T, endpoint string, extra ...string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "ssm.yaml")
+	require.NoError(t, os.WriteFile(p, []byte(`
+path: /fuse/prod
+region: us-east-1
+endpoint: `+endpoint+`
+access_key_id: AKIDEXAMPLE
+secret_access_key: wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY
+retry_max_delay: 1ms
+`+strings.Join(extra, "\n")), 0o600))
+	return p
+}
+
+func TestSSMProvider(t *testing.T) {
+	f := &fakeSSM{
+		t:        t,
+		throttle: 2,
+		params: []map[string]string{
+			{"Name": "/fuse/prod/SLACK_WEBHOOK", "Type": "SecureString", "Value": "https://hooks.slack.com/services/T/B/x"},
+			{"Name": "/fuse/prod/payments/opsgenie_key", "Type": "SecureString", "Value": "abc123"},
+			{"Name": "/fuse/prod/payments/region", "Type": "String", "Value": "eu-west-1"},
+		},
+	}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	p, err := secrets.NewProvider("ssm", ssmConfig(t, srv.URL))
+	require.NoError(t, err)
+
+	got, err := p.Resolve("SLACK_WEBHOOK")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/x", got)
+
+	got, err = p.Resolve("/fuse/prod/payments/opsgenie_key")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", got)
+
+	// Only SecureString parameters are exposed by default.
+	_, err = p.Resolve("payments/region")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+
+	_, err = p.Resolve("payments/missing")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+
+	// 2 throttled + 2 pages; later lookups are served from cache
+	assert.Equal(t, 4, f.calls)
+}
+
+func TestSSMProvider_Types(t *testing.T) {
+	f := &fakeSSM{t: t, params: []map[string]string{
+		{"Name": "/fuse/prod/payments/region", "Type": "String", "Value": "eu-west-1"},
+	}}
+	srv := httptest.NewServer(f)
+	defer srv.Close()
+
+	p, err := secrets.NewProvider("ssm", ssmConfig(t, srv.URL, "types: [SecureString, String]"))
+	require.NoError(t, err)
+
+	got, err := p.Resolve("payments/region")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", got)
+}
+
+func TestSSMProvider_Retries(t *testing.T) {
+	tests := []struct {
+		name      string
+		extra     string
+		wantCalls int
+	}{
+		{name: "default", wantCalls: 6}, // first attempt + 5 retries
+		{name: "custom", extra: "max_retries: 2", wantCalls: 3},
+		{name: "disabled", extra: "max_retries: 0", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeSSM{t: t, throttle: 100}
+			srv := httptest.NewServer(f)
+			defer srv.Close()
+
+			p, err := secrets.NewProvider("ssm", ssmConfig(t, srv.URL, tt.extra))
+			require.NoError(t, err)
+
+			_, err = p.Resolve("SLACK_WEBHOOK")
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, secrets.ErrNotFound)
+			assert.Contains(t, err.Error(), "ThrottlingException")
+			assert.Equal(t, tt.wantCalls, f.calls)
+		})
+	}
+}
+
+func TestSSMProvider_ConfigErrors(t *testing.T) {
+	_, err := secrets.NewSSMProviderFromConfig(secrets.SSMConfig{})
+	assert.ErrorContains(t, err, "'path' is required")
+
+	retries := -1
+	_, err = secrets.NewSSMProviderFromConfig(secrets.SSMConfig{Path: "/fuse", MaxRetries: &retries})
+	assert.ErrorContains(t, err, "max_retries")
+}
@@ -0,0 +1,326 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VaultConfig configures the HashiCorp Vault KV provider. It is read from the
+// file passed with --secrets-config.
+type VaultConfig struct {
+	// Address of the Vault server; defaults to VAULT_ADDR.
+	Address string `yaml:"address"`
+	// Namespace is sent as X-Vault-Namespace (Vault Enterprise); defaults to VAULT_NAMESPACE.
+	Namespace string `yaml:"namespace"`
+	// Mount is the KV secrets engine mount (default "secret").
+	Mount string `yaml:"mount"`
+	// KVVersion is 1 or 2 (default 2).
+	KVVersion int `yaml:"kv_version"`
+	// PathPrefix is prepended to every secret path, e.g. "fuse".
+	PathPrefix string    `yaml:"path_prefix"`
+	Auth       VaultAuth `yaml:"auth"`
+	// Timeout for each HTTP request (default 10s).
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// VaultAuth selects how the provider obtains a Vault token.
+type VaultAuth struct {
+	// Method is "token" (default) or "approle".
+	Method string `yaml:"method"`
+	// Token for the token method; defaults to VAULT_TOKEN.
+	Token string `yaml:"token"`
+	// RoleID and SecretID for AppRole; default to VAULT_ROLE_ID / VAULT_SECRET_ID.
+	RoleID   string `yaml:"role_id"`
+	SecretID string `yaml:"secret_id"`
+	// Mount is the AppRole auth mount (default "approle").
+	Mount string `yaml:"mount"`
+}
+
+// VaultProvider resolves placeholders from Vault KV v1 or v2.
+//
+// A key is split into a secret path and a field: "payments/slack_webhook"
+// reads field "slack_webhook" from <mount>/<path_prefix>/payments, and
+// "payments/webhooks#slack" reads field "slack" from .../payments/webhooks.
+// A key without a path reads the field from <path_prefix> itself and is an
+// error when no path_prefix is set; a KV v2 API path such as
+// "secret/data/payments#slack" is used as given. Missing secrets, including
+// paths Vault has no route for, are ErrNotFound. Every path is fetched at
+// most once per run.
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *http.Client
+
+	mu    sync.Mutex
+	token string
+	cache map[string]vaultEntry
+}
+
+type vaultEntry struct {
+	data map[string]any
+	err  error
+}
+
+// NewVaultProvider reads a VaultConfig from configPath and returns a provider.
+func NewVaultProvider(configPath string) (*VaultProvider, error) {
+	var cfg VaultConfig
+	if strings.TrimSpace(configPath) != "" {
+		b, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+		}
+		if err := yaml.Unmarshal(b, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+	}
+	return NewVaultProviderFromConfig(cfg)
+}
+
+// NewVaultProviderFromConfig applies defaults to cfg and returns a provider.
+func NewVaultProviderFromConfig(cfg VaultConfig) (*VaultProvider, error) {
+	if cfg.Address == "" {
+		cfg.Address = os.Getenv("VAULT_ADDR")
+	}
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("vault provider: address is required (config 'address' or VAULT_ADDR)")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	if cfg.KVVersion == 0 {
+		cfg.KVVersion = 2
+	}
+	if cfg.KVVersion != 1 && cfg.KVVersion != 2 {
+		return nil, fmt.Errorf("vault provider: unsupported kv_version %d", cfg.KVVersion)
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	switch cfg.Auth.Method {
+	case "", "token":
+		cfg.Auth.Method = "token"
+		if cfg.Auth.Token == "" {
+			cfg.Auth.Token = os.Getenv("VAULT_TOKEN")
+		}
+		if cfg.Auth.Token == "" {
+			return nil, fmt.Errorf("vault provider: token auth requires 'auth.token' or VAULT_TOKEN")
+		}
+	case "approle":
+		if cfg.Auth.RoleID == "" {
+			cfg.Auth.RoleID = os.Getenv("VAULT_ROLE_ID")
+		}
+		if cfg.Auth.SecretID == "" {
+			cfg.Auth.SecretID = os.Getenv("VAULT_SECRET_ID")
+		}
+		if cfg.Auth.RoleID == "" || cfg.Auth.SecretID == "" {
+			return nil, fmt.Errorf("vault provider: approle auth requires role_id and secret_id")
+		}
+		if cfg.Auth.Mount == "" {
+			cfg.Auth.Mount = "approle"
+		}
+	default:
+		return nil, fmt.Errorf("vault provider: unknown auth method %q", cfg.Auth.Method)
+	}
+
+	return &VaultProvider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+		token:  cfg.Auth.Token,
+		cache:  map[string]vaultEntry{},
+	}, nil
+}
+
+func (p *VaultProvider) Name() string { return "vault" }
+
+func (p *VaultProvider) Resolve(key string) (string, error) {
+	secretPath, field := splitVaultKey(key)
+	if field == "" {
+		return "", ErrNotFound
+	}
+
+	if strings.Trim(secretPath, "/") == "" && strings.Trim(p.cfg.PathPrefix, "/") == "" {
+		// This would read the mount itself, which is never a secret.
+		return "", fmt.Errorf("vault provider: key %q has no secret path and path_prefix is not set; use <path>#<field> or set path_prefix", key)
+	}
+
+	full := path.Join(p.cfg.PathPrefix, secretPath)
+	if p.cfg.KVVersion == 2 && strings.HasPrefix(secretPath, p.cfg.Mount+"/data/") {
+		// API-style path, as in ${vault:secret/data/payments#slack_webhook}
+		full = strings.TrimPrefix(secretPath, p.cfg.Mount+"/data/")
+	}
+
+	data, err := p.read(full)
+	if err != nil {
+		return "", err
+	}
+
+	v, ok := data[field]
+	if !ok {
+		return "", ErrNotFound
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(v), nil
+}
+
+// splitVaultKey splits "a/b#field" or "a/b/field" into path and field.
+func splitVaultKey(key string) (string, string) {
+	if i := strings.LastIndex(key, "#"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		return key[:i], key[i+1:]
+	}
+	return "", key
+}
+
+// read returns the secret data at secretPath (relative to the mount),
+// using the per-run cache.
+func (p *VaultProvider) read(secretPath string) (map[string]any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if e, ok := p.cache[secretPath]; ok {
+		return e.data, e.err
+	}
+
+	data, err := p.fetch(secretPath)
+	p.cache[secretPath] = vaultEntry{data: data, err: err}
+	return data, err
+}
+
+func (p *VaultProvider) fetch(secretPath string) (map[string]any, error) {
+	if p.token == "" {
+		if err := p.login(); err != nil {
+			return nil, err
+		}
+	}
+
+	var apiPath string
+	if p.cfg.KVVersion == 2 {
+		apiPath = path.Join("/v1", p.cfg.Mount, "data", secretPath)
+	} else {
+		apiPath = path.Join("/v1", p.cfg.Mount, secretPath)
+	}
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	status, err := p.do(http.MethodGet, apiPath, nil, &body)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	if p.cfg.KVVersion == 1 {
+		return body.Data, nil
+	}
+	inner, _ := body.Data["data"].(map[string]any)
+	if inner == nil {
+		// KV v2 returns data: null for deleted versions
+		return nil, ErrNotFound
+	}
+	return inner, nil
+}
+
+// login exchanges AppRole credentials for a client token.
+func (p *VaultProvider) login() error {
+	payload, err := json.Marshal(map[string]string{
+		"role_id":   p.cfg.Auth.RoleID,
+		"secret_id": p.cfg.Auth.SecretID,
+	})
+	if err != nil {
+		return err
+	}
+
+	var body struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	status, err := p.do(http.MethodPost, path.Join("/v1/auth", p.cfg.Auth.Mount, "login"), payload, &body)
+	if err != nil {
+		return fmt.Errorf("vault approle login: %w", err)
+	}
+	if status == http.StatusNotFound || body.Auth.ClientToken == "" {
+		return fmt.Errorf("vault approle login: no client token returned")
+	}
+	p.token = body.Auth.ClientToken
+	return nil
+}
+
+// do performs a Vault API request and decodes a JSON response into out.
+// Responses meaning "no such secret" are returned as a 404 status without
+// error so callers can map them to ErrNotFound.
+func (p *VaultProvider) do(method, apiPath string, payload []byte, out any) (int, error) {
+	url := strings.TrimRight(p.cfg.Address, "/") + apiPath
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	if p.token != "" {
+		req.Header.Set("X-Vault-Token", p.token)
+	}
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("vault %s %s: %w", method, apiPath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	}
+	if resp.StatusCode >= 300 {
+		var verr struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&verr)
+		if noSuchSecret(resp.StatusCode, verr.Errors) {
+			return http.StatusNotFound, nil
+		}
+		return resp.StatusCode, fmt.Errorf("vault %s %s: %s %s", method, apiPath, resp.Status, strings.Join(verr.Errors, "; "))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("vault %s %s: decode response: %w", method, apiPath, err)
+	}
+	return resp.StatusCode, nil
+}
+
+// noSuchSecret reports whether a failed response means the path holds no
+// secret. Vault answers paths outside any route, such as a mount's root, with
+// 400 or 405 rather than 404; authorization failures are real errors.
+func noSuchSecret(status int, errs []string) bool {
+	if status != http.StatusBadRequest && status != http.StatusMethodNotAllowed {
+		return false
+	}
+	for _, e := range errs {
+		if strings.Contains(e, "no handler for route") || strings.Contains(e, "unsupported path") || strings.Contains(e, "unsupported operation") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package secrets_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeVault mimics the subset of the Vault HTTP API used by the provider.
+type fakeVault struct {
+	token string
+	kv    map[string]map[string]any // API path -> data
+	reads map[string]int
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/v1/auth/approle/login" {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["role_id"] != "role" || body["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"invalid role or secret ID"}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"auth": map[string]any{"client_token": f.token}})
+		return
+	}
+
+	if r.Header.Get("X-Vault-Token") != f.token {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+		return
+	}
+
+	f.reads[r.URL.Path]++
+	data, ok := f.kv[r.URL.Path]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{}})
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func writeVaultConfig(t *testing.T, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), "vault.yaml")
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o600))
+	return p
+}
+
+func TestVaultProvider_KVv2Token(t *testing.T) {
+	fv := &fakeVault{
+		token: "t0k3n",
+		kv: map[string]map[string]any{
+			"/v1/secret/data/fuse/payments": {
+				"data":     map[string]any{"slack_webhook": "https://hooks.slack.com/services/T/B/x"},
+				"metadata": map[string]any{"version": 3},
+			},
+			"/v1/secret/data/fuse/payments/webhooks": {
+				"data": map[string]any{"teams": "https://outlook.office.com/webhook/x"},
+			},
+		},
+		reads: map[string]int{},
+	}
+	srv := httptest.NewServer(fv)
+	defer srv.Close()
+
+	cfg := writeVaultConfig(t, `
+address: `+srv.URL+`
+mount: secret
+path_prefix: fuse
+auth:
+  method: token
+  token: t0k3n
+`)
+
+	p, err := secrets.NewProvider("vault", cfg)
+	require.NoError(t, err)
+
+	got, err := p.Resolve("payments/slack_webhook")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/x", got)
+
+	got, err = p.Resolve("payments/webhooks#teams")
+	require.NoError(t, err)
+	assert.Equal(t, "https://outlook.office.com/webhook/x", got)
+
+	_, err = p.Resolve("payments/missing_field")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+
+	_, err = p.Resolve("billing/slack_webhook")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+	_, err = p.Resolve("billing/other")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+
+	// each path is read once per run
+	assert.Equal(t, 1, fv.reads["/v1/secret/data/fuse/payments"])
+	assert.Equal(t, 1, fv.reads["/v1/secret/data/fuse/billing"])
+}
+
+func TestVaultProvider_KVv1AppRole(t *testing.T) {
+	fv := &fakeVault{
+		token: "approle-token",
+		kv: map[string]map[string]any{
+			"/v1/kv/payments": {"opsgenie_key": "abc123"},
+		},
+		reads: map[string]int{},
+	}
+	srv := httptest.NewServer(fv)
+	defer srv.Close()
+
+	cfg := writeVaultConfig(t, `
+address: `+srv.URL+`
+mount: kv
+kv_version: 1
+auth:
+  method: approle
+  role_id: role
+  secret_id: secret
+`)
+
+	p, err := secrets.NewProvider("vault", cfg)
+	require.NoError(t, err)
+
+	got, err := p.Resolve("payments/opsgenie_key")
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", got)
+}
+
+func TestVaultProvider_PermissionDenied(t *testing.T) {
+	fv := &fakeVault{token: "right", kv: map[string]map[string]any{}, reads: map[string]int{}}
+	srv := httptest.NewServer(fv)
+	defer srv.Close()
+
+	cfg := writeVaultConfig(t, "address: "+srv.URL+"\nauth:\n  token: wrong\n")
+
+	p, err := secrets.NewProvider("vault", cfg)
+	require.NoError(t, err)
+
+	_, err = p.Resolve("payments/slack_webhook")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, secrets.ErrNotFound)
+	assert.Contains(t, err.Error(), "permission denied")
+}
+
+func TestVaultProvider_KeyWithoutPath(t *testing.T) {
+	fv := &fakeVault{token: "t", kv: map[string]map[string]any{
+		"/v1/secret/data/fuse": {"data": map[string]any{"slack_webhook": "https://hooks.example.com/x"}},
+	}, reads: map[string]int{}}
+	srv := httptest.NewServer(fv)
+	defer srv.Close()
+
+	p, err := secrets.NewProvider("vault", writeVaultConfig(t, "address: "+srv.URL+"\nauth:\n  token: t\n"))
+	require.NoError(t, err)
+
+	_, err = p.Resolve("slack_webhook")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, secrets.ErrNotFound)
+	assert.Contains(t, err.Error(), "path_prefix")
+	assert.Empty(t, fv.reads, "the mount root must not be read")
+
+	p, err = secrets.NewProvider("vault", writeVaultConfig(t, "address: "+srv.URL+"\npath_prefix: fuse\nauth:\n  token: t\n"))
+	require.NoError(t, err)
+
+	got, err := p.Resolve("slack_webhook")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/x", got)
+}
+
+// Vault answers paths it has no route for with 405 rather than 404; a chain
+// must still fall through to the next provider.
+func TestVaultProvider_UnsupportedPathFallsThrough(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"1 error occurred:\n\t* unsupported path\n\n"}})
+	}))
+	defer srv.Close()
+
+	vault, err := secrets.NewProvider("vault", writeVaultConfig(t, "address: "+srv.URL+"\nauth:\n  token: t\n"))
+	require.NoError(t, err)
+
+	_, err = vault.Resolve("payments/slack_webhook")
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+
+	t.Setenv("payments/slack_webhook", "from-env")
+	chain, err := secrets.NewChainProvider([]secrets.ChainEntry{
+		{Name: "vault", Provider: vault},
+		{Name: "env", Provider: &secrets.EnvProvider{}},
+	})
+	require.NoError(t, err)
+
+	got, err := chain.Resolve("payments/slack_webhook")
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", got)
+}
+
+func TestVaultProvider_ConfigErrors(t *testing.T) {
+	t.Setenv("VAULT_ADDR", "")
+	t.Setenv("VAULT_TOKEN", "")
+
+	_, err := secrets.NewProvider("vault", writeVaultConfig(t, "mount: secret\n"))
+	assert.ErrorContains(t, err, "address is required")
+
+	_, err = secrets.NewProvider("vault", writeVaultConfig(t, "address: http://vault:8200\n"))
+	assert.ErrorContains(t, err, "VAULT_TOKEN")
+
+	_, err = secrets.NewProvider("vault", writeVaultConfig(t, "address: http://vault:8200\nauth:\n  method: approle\n"))
+	assert.ErrorContains(t, err, "role_id")
+}
+
+func TestInterpolateString_PathStyleKeys(t *testing.T) {
+	fv := &fakeVault{
+		token: "t",
+		kv: map[string]map[string]any{
+			"/v1/secret/data/payments": {"data": map[string]any{"slack_webhook": "https://example"}},
+		},
+		reads: map[string]int{},
+	}
+	srv := httptest.NewServer(fv)
+	defer srv.Close()
+
+	p, err := secrets.NewProvider("vault", writeVaultConfig(t, "address: "+srv.URL+"\nauth:\n  token: t\n"))
+	require.NoError(t, err)
+
+	out, missing, err := secrets.InterpolateString("url=${payments/slack_webhook}", p)
+	require.NoError(t, err)
+	assert.Empty(t, missing)
+	assert.Equal(t, "url=https://example", out)
+}
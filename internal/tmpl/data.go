@@ -0,0 +1,237 @@
+package tmpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"time"
+)
+
+// Data is the value notification templates are executed with. Field names
+// and JSON keys match Alertmanager's template data (and webhook payload), so
+// a captured webhook body can be used as a fixture as-is.
+type Data struct {
+	Receiver          string `json:"receiver"`
+	Status            string `json:"status"`
+	Alerts            Alerts `json:"alerts"`
+	GroupLabels       KV     `json:"groupLabels"`
+	CommonLabels      KV     `json:"commonLabels"`
+	CommonAnnotations KV     `json:"commonAnnotations"`
+	ExternalURL       string `json:"externalURL"`
+}
+
+// Alert is a single alert of a notification.
+type Alert struct {
+	Status       string    `json:"status"`
+	Labels       KV        `json:"labels"`
+	Annotations  KV        `json:"annotations"`
+	StartsAt     time.Time `json:"startsAt"`
+	EndsAt       time.Time `json:"endsAt"`
+	GeneratorURL string    `json:"generatorURL"`
+	Fingerprint  string    `json:"fingerprint"`
+}
+
+// Alert states.
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Alerts is a list of alerts.
+type Alerts []Alert
+
+// Firing returns the firing alerts.
+func (as Alerts) Firing() []Alert {
+	return as.withStatus(StatusFiring)
+}
+
+// Resolved returns the resolved alerts.
+func (as Alerts) Resolved() []Alert {
+	return as.withStatus(StatusResolved)
+}
+
+func (as Alerts) withStatus(status string) []Alert {
+	var out []Alert
+	for _, a := range as {
+		if a.Status == status {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// KV is a set of label or annotation pairs.
+type KV map[string]string
+
+// Pair is a key/value pair of a KV.
+type Pair struct {
+	Name, Value string
+}
+
+// Pairs is a list of key/value pairs.
+type Pairs []Pair
+
+// Names returns the names of the pairs.
+func (ps Pairs) Names() []string {
+	out := make([]string, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, p.Name)
+	}
+	return out
+}
+
+// Values returns the values of the pairs.
+func (ps Pairs) Values() []string {
+	out := make([]string, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, p.Value)
+	}
+	return out
+}
+
+// SortedPairs returns the pairs sorted by name.
+func (kv KV) SortedPairs() Pairs {
+	out := make(Pairs, 0, len(kv))
+	for _, k := range kv.Names() {
+		out = append(out, Pair{Name: k, Value: kv[k]})
+	}
+	return out
+}
+
+// Remove returns a copy of kv without the given keys.
+func (kv KV) Remove(keys []string) KV {
+	out := KV{}
+	for k, v := range kv {
+		out[k] = v
+	}
+	for _, k := range keys {
+		delete(out, k)
+	}
+	return out
+}
+
+// Names returns the sorted names.
+func (kv KV) Names() []string {
+	out := make([]string, 0, len(kv))
+	for k := range kv {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Values returns the values, sorted by name.
+func (kv KV) Values() []string {
+	return kv.SortedPairs().Values()
+}
+
+// NewData builds the data of a notification for alerts grouped by groupBy.
+func NewData(receiver string, alerts Alerts, groupBy []string, externalURL string) *Data {
+	d := &Data{Receiver: receiver, Alerts: alerts, ExternalURL: externalURL}
+	d.Complete(groupBy)
+	return d
+}
+
+// ReadFixture decodes an alert fixture: either a JSON list of alerts (as
+// posted to Alertmanager's API) or a JSON object shaped like Data. Call
+// Complete to derive the fields the fixture leaves out.
+func ReadFixture(r io.Reader) (*Data, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var d Data
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		err = json.Unmarshal(b, &d.Alerts)
+	} else {
+		err = json.Unmarshal(b, &d)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid alert fixture: %w", err)
+	}
+	if len(d.Alerts) == 0 {
+		return nil, fmt.Errorf("invalid alert fixture: no alerts")
+	}
+	return &d, nil
+}
+
+// Complete fills the fields Alertmanager derives from the alerts when they
+// are unset: each alert's status and fingerprint, the group status, the
+// group labels (taken from the first alert for the groupBy labels) and the
+// labels and annotations all alerts share.
+func (d *Data) Complete(groupBy []string) {
+	now := time.Now()
+	for i := range d.Alerts {
+		a := &d.Alerts[i]
+		if a.Status == "" {
+			a.Status = StatusFiring
+			if !a.EndsAt.IsZero() && a.EndsAt.Before(now) {
+				a.Status = StatusResolved
+			}
+		}
+		if a.Fingerprint == "" {
+			a.Fingerprint = fingerprint(a.Labels)
+		}
+	}
+
+	if d.Status == "" {
+		d.Status = StatusResolved
+		if len(d.Alerts.Firing()) > 0 {
+			d.Status = StatusFiring
+		}
+	}
+
+	if d.GroupLabels == nil {
+		d.GroupLabels = KV{}
+		if len(d.Alerts) > 0 {
+			for _, l := range groupBy {
+				if v, ok := d.Alerts[0].Labels[l]; ok {
+					d.GroupLabels[l] = v
+				}
+			}
+		}
+	}
+	if d.CommonLabels == nil {
+		d.CommonLabels = common(d.Alerts, func(a Alert) KV { return a.Labels })
+	}
+	if d.CommonAnnotations == nil {
+		d.CommonAnnotations = common(d.Alerts, func(a Alert) KV { return a.Annotations })
+	}
+}
+
+// common returns the pairs every alert has.
+func common(alerts Alerts, kv func(Alert) KV) KV {
+	out := KV{}
+	if len(alerts) == 0 {
+		return out
+	}
+	for k, v := range kv(alerts[0]) {
+		out[k] = v
+	}
+	for _, a := range alerts[1:] {
+		other := kv(a)
+		for k, v := range out {
+			if ov, ok := other[k]; !ok || ov != v {
+				delete(out, k)
+			}
+		}
+	}
+	return out
+}
+
+// fingerprint hashes a label set the way Prometheus does (FNV-64a over the
+// sorted pairs), so fixtures without one get stable, realistic values.
+func fingerprint(labels KV) string {
+	h := fnv.New64a()
+	for _, p := range labels.SortedPairs() {
+		h.Write([]byte(p.Name))
+		h.Write([]byte{0xff})
+		h.Write([]byte(p.Value))
+		h.Write([]byte{0xff})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
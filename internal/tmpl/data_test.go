@@ -0,0 +1,64 @@
+package tmpl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/tmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFixture(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		wantErr     bool
+		wantStatus  string
+		wantGroup   tmpl.KV
+		wantCommon  tmpl.KV
+		wantFirings int
+	}{
+		{
+			name: "list of alerts",
+			fixture: `[
+				{"labels": {"alertname": "Down", "env": "prod", "instance": "a"}},
+				{"labels": {"alertname": "Down", "env": "prod", "instance": "b"}, "endsAt": "2020-01-01T00:00:00Z"}
+			]`,
+			wantStatus:  "firing",
+			wantGroup:   tmpl.KV{"alertname": "Down"},
+			wantCommon:  tmpl.KV{"alertname": "Down", "env": "prod"},
+			wantFirings: 1,
+		},
+		{
+			name: "webhook payload keeps given fields",
+			fixture: `{"status": "resolved", "groupLabels": {"env": "prod"},
+				"alerts": [{"status": "resolved", "labels": {"alertname": "Down", "env": "prod"}}]}`,
+			wantStatus: "resolved",
+			wantGroup:  tmpl.KV{"env": "prod"},
+			wantCommon: tmpl.KV{"alertname": "Down", "env": "prod"},
+		},
+		{name: "no alerts", fixture: `{"alerts": []}`, wantErr: true},
+		{name: "not json", fixture: `alerts: []`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := tmpl.ReadFixture(strings.NewReader(tt.fixture))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			d.Complete([]string{"alertname"})
+
+			assert.Equal(t, tt.wantStatus, d.Status)
+			assert.Equal(t, tt.wantGroup, d.GroupLabels)
+			assert.Equal(t, tt.wantCommon, d.CommonLabels)
+			assert.Len(t, d.Alerts.Firing(), tt.wantFirings)
+			for _, a := range d.Alerts {
+				assert.Len(t, a.Fingerprint, 16)
+			}
+		})
+	}
+}
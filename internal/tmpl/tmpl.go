@@ -0,0 +1,215 @@
+// Package tmpl parses notification templates the way Alertmanager does, with
+// the same function map, so template files can be checked before deploying.
+package tmpl
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	tt "text/template"
+	"time"
+	"unicode"
+)
+
+// FuncMap mirrors the functions Alertmanager makes available to templates.
+var FuncMap = tt.FuncMap{
+	"toUpper":   strings.ToUpper,
+	"toLower":   strings.ToLower,
+	"title":     title,
+	"trimSpace": strings.TrimSpace,
+	// join is equal to strings.Join but inverts the argument order
+	// for easier pipelining in templates.
+	"join": func(sep string, s []string) string {
+		return strings.Join(s, sep)
+	},
+	"match": regexp.MatchString,
+	"safeHtml": func(text string) template.HTML {
+		return template.HTML(text)
+	},
+	"safeUrl": func(text string) template.URL {
+		return template.URL(text)
+	},
+	"urlUnescape": url.QueryUnescape,
+	"reReplaceAll": func(pattern, repl, text string) string {
+		re := regexp.MustCompile(pattern)
+		return re.ReplaceAllString(text, repl)
+	},
+	"stringSlice": func(s ...string) []string {
+		return s
+	},
+	"date": func(fmt string, t time.Time) string {
+		return t.Format(fmt)
+	},
+	"tz": func(name string, t time.Time) (time.Time, error) {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return t.In(loc), nil
+	},
+	"since":            time.Since,
+	"humanizeDuration": humanizeDuration,
+	"toJson": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// Parse parses a template file with FuncMap. name is used in error messages.
+func Parse(name, content string) (*tt.Template, error) {
+	return tt.New(name).Option("missingkey=zero").Funcs(FuncMap).Parse(content)
+}
+
+// defaults are Alertmanager's own templates, which define names such as
+// slack.default.title that configs and team templates may use.
+//
+//go:embed defaults/default.tmpl defaults/email.tmpl
+var defaults embed.FS
+
+// Set is the collection of template files a rendered field can reference.
+type Set struct {
+	t *tt.Template
+}
+
+// NewSet returns a template set holding Alertmanager's default templates.
+// Like Alertmanager, files added later may redefine their names.
+func NewSet() *Set {
+	t := tt.New("").Option("missingkey=zero").Funcs(FuncMap)
+	for _, name := range []string{"default.tmpl", "email.tmpl"} {
+		content, err := defaults.ReadFile("defaults/" + name)
+		if err != nil {
+			panic(err)
+		}
+		tt.Must(t.New(name).Parse(string(content)))
+	}
+	return &Set{t: t}
+}
+
+// Add parses a template file into the set.
+func (s *Set) Add(name, content string) error {
+	if _, err := s.t.New(name).Parse(content); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ExecuteText executes a config field such as a Slack title against data,
+// the way Alertmanager does, with the set's named templates in scope.
+func (s *Set) ExecuteText(text string, data *Data) (string, error) {
+	t, err := s.t.Clone()
+	if err != nil {
+		return "", err
+	}
+	t, err = t.New("field").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var buf strings.Builder
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// errLineRe extracts the line from text/template errors such as
+// `template: slack.tmpl:3: function "foo" not defined`.
+var errLineRe = regexp.MustCompile(`^template: [^:]+:(\d+):`)
+
+// ErrorLine returns the line number a parse error points at, or 0.
+func ErrorLine(err error) int {
+	m := errLineRe.FindStringSubmatch(err.Error())
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(m[1])
+	return n
+}
+
+// Define is a {{ define "name" }} block of a template file.
+type Define struct {
+	Name string
+	Line int
+}
+
+var defineRe = regexp.MustCompile(`\{\{-?\s*define\s+"([^"]+)"`)
+
+// Defines lists the named templates a file defines, in order.
+func Defines(content string) []Define {
+	var out []Define
+	for i, line := range strings.Split(content, "\n") {
+		for _, m := range defineRe.FindAllStringSubmatch(line, -1) {
+			out = append(out, Define{Name: m[1], Line: i + 1})
+		}
+	}
+	return out
+}
+
+func title(s string) string {
+	prev := ' '
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(prev) || unicode.IsPunct(prev) {
+			prev = r
+			return unicode.ToTitle(r)
+		}
+		prev = r
+		return r
+	}, s)
+}
+
+// humanizeDuration formats seconds as e.g. "1h 2m 3s", like Alertmanager.
+func humanizeDuration(i any) (string, error) {
+	var v float64
+	switch n := i.(type) {
+	case time.Duration:
+		v = n.Seconds()
+	case int:
+		v = float64(n)
+	case int64:
+		v = float64(n)
+	case float64:
+		v = n
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return "", err
+		}
+		v = f
+	default:
+		return "", fmt.Errorf("humanizeDuration: unsupported type %T", i)
+	}
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	if v == 0 {
+		return "0s", nil
+	}
+	if math.Abs(v) < 1 {
+		return fmt.Sprintf("%.4gms", v*1000), nil
+	}
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	secs := int64(v)
+	days, hours, minutes := secs/86400, secs/3600%24, secs/60%60
+	seconds := v - float64(secs/60*60)
+
+	switch {
+	case days != 0:
+		return fmt.Sprintf("%s%dd %dh %dm %.4gs", sign, days, hours, minutes, seconds), nil
+	case hours != 0:
+		return fmt.Sprintf("%s%dh %dm %.4gs", sign, hours, minutes, seconds), nil
+	case minutes != 0:
+		return fmt.Sprintf("%s%dm %.4gs", sign, minutes, seconds), nil
+	}
+	return fmt.Sprintf("%s%.4gs", sign, seconds), nil
+}
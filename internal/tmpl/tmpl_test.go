@@ -0,0 +1,114 @@
+package tmpl_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/tmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndExecute(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+		data any
+		want string
+	}{
+		{name: "toUpper", tmpl: `{{ .Status | toUpper }}`, data: map[string]any{"Status": "firing"}, want: "FIRING"},
+		{name: "title", tmpl: `{{ title "payments api-down" }}`, want: "Payments Api-Down"},
+		{name: "join", tmpl: `{{ stringSlice "a" "b" | join ", " }}`, want: "a, b"},
+		{name: "reReplaceAll", tmpl: `{{ reReplaceAll "(.*):.*" "$1" "host:9090" }}`, want: "host"},
+		{name: "humanizeDuration", tmpl: `{{ humanizeDuration 3723 }}`, want: "1h 2m 3s"},
+		{name: "humanizeDuration sub-second", tmpl: `{{ humanizeDuration 0.25 }}`, want: "250ms"},
+		{name: "toJson", tmpl: `{{ toJson .L }}`, data: map[string]any{"L": map[string]string{"a": "b"}}, want: `{"a":"b"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := tmpl.Parse(tt.name, tt.tmpl)
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, tp.Execute(&buf, tt.data))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestParseErrorsAndDefines(t *testing.T) {
+	_, err := tmpl.Parse("slack.tmpl", "line one\n\n{{ if }}")
+	require.Error(t, err)
+	assert.Equal(t, 3, tmpl.ErrorLine(err))
+
+	content := "{{ define \"a\" }}x{{ end }}\n\n{{- define \"b\" -}}\ny\n{{ end }}"
+	assert.Equal(t, []tmpl.Define{{Name: "a", Line: 1}, {Name: "b", Line: 3}}, tmpl.Defines(content))
+}
+
+func TestSetExecuteText(t *testing.T) {
+	set := tmpl.NewSet()
+	require.NoError(t, set.Add("slack.tmpl", `{{ define "slack.title" }}[{{ .Status | toUpper }}] {{ .GroupLabels.alertname }}{{ end }}`))
+
+	data := tmpl.NewData("payments/slack", tmpl.Alerts{
+		{Labels: tmpl.KV{"alertname": "HighCPU", "instance": "a"}},
+		{Labels: tmpl.KV{"alertname": "HighCPU", "instance": "b"}},
+	}, []string{"alertname"}, "http://am")
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "named template", text: `{{ template "slack.title" . }}`, want: "[FIRING] HighCPU"},
+		{name: "alerts", text: `{{ range .Alerts.Firing }}{{ .Labels.instance }} {{ end }}`, want: "a b "},
+		{name: "sorted pairs", text: `{{ .CommonLabels.SortedPairs.Names | join "," }}`, want: "alertname"},
+		{name: "remove", text: `{{ (.Alerts.Firing | len) }} {{ .CommonLabels.Remove (stringSlice "alertname") | len }}`, want: "2 0"},
+		{name: "receiver and url", text: `{{ .Receiver }} {{ .ExternalURL }}`, want: "payments/slack http://am"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := set.ExecuteText(tt.text, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := set.ExecuteText(`{{ template "missing" . }}`, data)
+	assert.Error(t, err)
+}
+
+func TestSetDefaultsAndFiles(t *testing.T) {
+	set := tmpl.NewSet()
+	// Files of the same base name from two teams both stay in the set.
+	require.NoError(t, set.Add("templates/payments/slack.tmpl", `{{ define "payments.title" }}payments{{ end }}`))
+	require.NoError(t, set.Add("templates/search/slack.tmpl", `{{ define "search.title" }}search{{ end }}`))
+
+	data := tmpl.NewData("payments/slack", tmpl.Alerts{
+		{Labels: tmpl.KV{"alertname": "HighCPU", "instance": "a"}},
+	}, []string{"alertname"}, "http://am")
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "alertmanager default", text: `{{ template "slack.default.title" . }}`, want: "[FIRING:1] HighCPU (a)"},
+		{name: "first team", text: `{{ template "payments.title" . }}`, want: "payments"},
+		{name: "second team", text: `{{ template "search.title" . }}`, want: "search"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := set.ExecuteText(tt.text, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	// Like Alertmanager, a file may redefine a default template.
+	require.NoError(t, set.Add("templates/payments/override.tmpl", `{{ define "slack.default.title" }}custom{{ end }}`))
+	got, err := set.ExecuteText(`{{ template "slack.default.title" . }}`, data)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", got)
+}
@@ -3,6 +3,8 @@ package validate
 import (
 	"sort"
 
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/config"
 	"github.com/nyambati/fuse/internal/diag"
 	"github.com/nyambati/fuse/internal/dsl"
 	"github.com/nyambati/fuse/internal/validate/validators"
@@ -11,10 +13,14 @@ import (
 // Options for validation behavior
 type Options struct {
 	Strict bool
+	// SecretAllowlist acknowledges secret-scan findings (from .fuse.yaml).
+	SecretAllowlist []config.SecretAllow
+	// ReceiverPattern names team receivers (receivers.name_pattern).
+	ReceiverPattern string
 }
 
 // Project runs semantic validation on a loaded DSL project and the derived AM config.
-func Project(proj dsl.Project, amc any, opts Options) []diag.Diagnostic {
+func Project(proj dsl.Project, amc am.Config, opts Options) []diag.Diagnostic {
 	var diags []diag.Diagnostic
 
 	// ---- Basic project-level checks ----
@@ -26,12 +32,19 @@ func Project(proj dsl.Project, amc any, opts Options) []diag.Diagnostic {
 		})
 	}
 
+	// Global channels are checked like a team's channels.
+	owners := append([]dsl.Team{proj.GlobalTeam()}, proj.Teams...)
+
 	validators := []validators.Validator{
-		validators.NewTeamValidator(proj.Teams),
-		validators.NewFlowValidator(proj.Teams),
-		validators.NewChannelsValidator(proj.Teams),
+		validators.NewTeamValidator(proj.Teams, proj.Channels),
+		validators.NewFlowValidator(proj),
+		validators.NewChannelsValidator(owners),
+		validators.NewReceiverNamesValidator(owners, opts.ReceiverPattern),
+		validators.NewSecretLeakValidator(owners, opts.SecretAllowlist),
+		validators.NewTemplatesValidator(proj.Teams),
 		validators.NewInhibitorsValidator(proj),
 		validators.NewSilenceWindowsValidator(proj),
+		validators.NewAlertmanagerValidator(amc, proj, opts.ReceiverPattern),
 	}
 
 	for _, v := range validators {
@@ -64,21 +77,37 @@ func Merge(diags ...[]diag.Diagnostic) []diag.Diagnostic {
 	return all
 }
 
+// externalFailureCodes are diagnostics caused by an external tool or secrets
+// provider rather than by the project itself.
+var externalFailureCodes = map[string]struct{}{
+	"AMTOOL_NOT_FOUND":       {},
+	"AMTOOL_EXEC_FAILED":     {},
+	"AMTOOL_EXIT_STATUS":     {},
+	"SECRET_PROVIDER_FAILED": {},
+}
+
 // ExitCode returns the exit code based on diagnostics and strict mode
 // 0 = no issues
 // 2 = warnings only (strict=false)
 // 3 = errors found
+// 4 = external tool/provider failure
 func ExitCode(diags []diag.Diagnostic, strict bool) int {
-	var hasWarn, hasErr bool
+	var hasWarn, hasErr, hasExternal bool
 	for _, d := range diags {
 		switch d.Level {
 		case diag.LevelWarn:
 			hasWarn = true
 		case diag.LevelError:
 			hasErr = true
+			if _, ok := externalFailureCodes[d.Code]; ok {
+				hasExternal = true
+			}
 		}
 	}
 
+	if hasExternal {
+		return 4
+	}
 	if hasErr {
 		return 3
 	}
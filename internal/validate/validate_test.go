@@ -0,0 +1,115 @@
+package validate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/parse"
+	"github.com/nyambati/fuse/internal/secrets"
+	"github.com/nyambati/fuse/internal/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		diags  []diag.Diagnostic
+		strict bool
+		want   int
+	}{
+		{name: "clean", want: 0},
+		{name: "info only", diags: []diag.Diagnostic{diag.Info("X", "x", "")}, want: 0},
+		{name: "warnings", diags: []diag.Diagnostic{diag.Warn("X", "x", "")}, want: 2},
+		{name: "warnings strict", diags: []diag.Diagnostic{diag.Warn("X", "x", "")}, strict: true, want: 3},
+		{name: "errors", diags: []diag.Diagnostic{diag.Error("X", "x", "")}, want: 3},
+		{
+			name: "amtool failure wins over errors",
+			diags: []diag.Diagnostic{
+				diag.Error("AMTOOL_CHECK_FAILED", "x", ""),
+				diag.Error("AMTOOL_EXIT_STATUS", "x", ""),
+			},
+			want: 4,
+		},
+		{name: "missing amtool", diags: []diag.Diagnostic{diag.Error("AMTOOL_NOT_FOUND", "x", "")}, want: 4},
+		{name: "secrets provider failure", diags: []diag.Diagnostic{diag.Error("SECRET_PROVIDER_FAILED", "x", "")}, want: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, validate.ExitCode(tt.diags, tt.strict))
+		})
+	}
+}
+
+// Route errors point at the DSL line that caused them and are reported once,
+// not again by the Alertmanager checks of the generated config.
+func TestProject_LocatesRouteErrors(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"global/global.yaml": "global: {}\n",
+		"global/channels.yaml": `channels:
+  - name: default
+    type: webhook
+    configs:
+      - url: http://127.0.0.1:5001/
+`,
+		"global/root_route.yaml": `route:
+  receiver: global:default
+  group_interval: 0s
+`,
+		"teams/payments/channels.yaml": `channels:
+  - name: hook
+    type: webhook
+    configs:
+      - url: http://127.0.0.1:5002/
+`,
+		"teams/payments/flows.yaml": `flows:
+  - when: {severity: critical}
+    notify: hook
+    wait_for: 30x
+  - when: {severity: warning}
+    notify: nope
+  - when: {severity: info}
+    notify: hook
+    silence_when: [missing]
+`,
+		"teams/payments/silence_windows.yaml": "silence_windows: []\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	proj, loadDiags := dsl.LoadProject(root, nil)
+	require.Empty(t, loadDiags)
+	amc, parseDiags := parse.ToAlertmanager(proj, &secrets.EnvProvider{}, parse.Options{})
+	diags := validate.Merge(parseDiags, validate.Project(proj, amc, validate.Options{}))
+
+	type loc struct {
+		File string
+		Line int
+	}
+	got := map[string]loc{}
+	for _, d := range diags {
+		if d.Level != diag.LevelError {
+			continue
+		}
+		_, dup := got[d.Code]
+		assert.False(t, dup, "%s reported more than once", d.Code)
+		rel, _ := filepath.Rel(root, d.File)
+		got[d.Code] = loc{rel, d.Line}
+	}
+
+	flows := filepath.Join("teams", "payments", "flows.yaml")
+	assert.Equal(t, map[string]loc{
+		"AM_DURATION_ZERO":      {filepath.Join("global", "root_route.yaml"), 3},
+		"FLOW_DURATION_INVALID": {flows, 4},
+		"FLOW_NOTIFY_UNKNOWN":   {flows, 6},
+		"FLOW_SILENCE_UNKNOWN":  {flows, 9},
+	}, got)
+}
@@ -0,0 +1,266 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// AlertmanagerValidator checks the generated Alertmanager config against the
+// rules Alertmanager applies when loading it, so configs can be validated
+// without amtool.
+//
+// Routes built from teams and flows (those with a Source) are checked by the
+// flow and team validators, which point at the DSL; here they are only
+// checked for what those cannot see, such as a notify target whose channel
+// produced no receiver. The root route is located in global/root_route.yaml.
+type AlertmanagerValidator struct {
+	cfg  am.Config
+	root dsl.Source
+	// channels and windows hold the receiver and time interval names the
+	// project's channels and silence windows are rendered as.
+	channels map[string]struct{}
+	windows  map[string]struct{}
+}
+
+// NewAlertmanagerValidator creates a validator for the config derived from
+// proj; receiverPattern is the one the config was built with.
+func NewAlertmanagerValidator(cfg am.Config, proj dsl.Project, receiverPattern string) Validator {
+	v := AlertmanagerValidator{
+		cfg:      cfg,
+		root:     proj.RootRouteFile,
+		channels: map[string]struct{}{},
+		windows:  map[string]struct{}{},
+	}
+	for _, sw := range proj.SilenceWindows {
+		v.windows[dsl.WindowName(receiverPattern, dsl.GlobalScope, sw.Name)] = struct{}{}
+	}
+	for _, t := range append([]dsl.Team{proj.GlobalTeam()}, proj.Teams...) {
+		for _, ch := range t.Channels {
+			v.channels[dsl.ReceiverName(receiverPattern, t.Name, ch.Name)] = struct{}{}
+		}
+		if t.Name == dsl.GlobalScope {
+			continue
+		}
+		for _, sw := range t.SilenceWindows {
+			v.windows[dsl.WindowName(receiverPattern, t.Name, sw.Name)] = struct{}{}
+		}
+	}
+	return v
+}
+
+func (v AlertmanagerValidator) Validate() []diag.Diagnostic {
+	var diags []diag.Diagnostic
+
+	// ---- Receivers: named and unique ----
+	receivers := map[string]struct{}{}
+	for i, r := range v.cfg.Receivers {
+		name := strings.TrimSpace(r.Name)
+		if name == "" {
+			diags = append(diags, diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_RECEIVER_NO_NAME",
+				Message: fmt.Sprintf("receivers[%d] has no name", i),
+			})
+			continue
+		}
+		if _, exists := receivers[name]; exists {
+			diags = append(diags, diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_RECEIVER_DUP",
+				Message: fmt.Sprintf("receiver %q is defined more than once", name),
+			})
+		}
+		receivers[name] = struct{}{}
+	}
+
+	// ---- Time intervals: named and unique ----
+	intervals := map[string]struct{}{}
+	for _, ti := range v.cfg.TimeIntervals {
+		if _, exists := intervals[ti.Name]; exists {
+			diags = append(diags, diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_TIME_INTERVAL_DUP",
+				Message: fmt.Sprintf("time interval %q is defined more than once", ti.Name),
+			})
+		}
+		intervals[ti.Name] = struct{}{}
+	}
+
+	// ---- Global durations ----
+	if rt, ok := v.cfg.Global["resolve_timeout"]; ok {
+		if s, ok := rt.(string); !ok {
+			diags = append(diags, diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_DURATION_INVALID",
+				Message: fmt.Sprintf("global.resolve_timeout must be a duration string, got %v", rt),
+			})
+		} else if _, err := am.ParseDuration(s); err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_DURATION_INVALID",
+				Message: fmt.Sprintf("global.resolve_timeout: %v", err),
+			})
+		}
+	}
+
+	// ---- Root route ----
+	root := v.cfg.Route
+	if strings.TrimSpace(root.Receiver) == "" {
+		diags = append(diags, v.root.KeyPos("receiver").Locate(diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "AM_ROOT_NO_RECEIVER",
+			Message: "root route must specify a default receiver (global/root_route.yaml)",
+		}))
+	}
+	if len(root.Matchers) > 0 {
+		diags = append(diags, v.root.KeyPos("matchers").Locate(diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "AM_ROOT_MATCHERS",
+			Message: "root route must not have any matchers",
+		}))
+	}
+	if root.Continue {
+		diags = append(diags, v.root.KeyPos("continue").Locate(diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "AM_ROOT_CONTINUE",
+			Message: "root route must not set continue",
+		}))
+	}
+	if len(root.MuteTimeIntervals) > 0 || len(root.ActiveTimeIntervals) > 0 {
+		key := "mute_time_intervals"
+		if len(root.MuteTimeIntervals) == 0 {
+			key = "active_time_intervals"
+		}
+		diags = append(diags, v.root.KeyPos(key).Locate(diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "AM_ROOT_TIME_INTERVALS",
+			Message: "root route must not have mute or active time intervals",
+		}))
+	}
+
+	diags = append(diags, v.validateRoute(root, "route", v.root, receivers, intervals)...)
+
+	// ---- Inhibit rules: matchers must parse ----
+	for i, ir := range v.cfg.InhibitRules {
+		for _, side := range []struct {
+			field    string
+			matchers []string
+		}{
+			{"source_matchers", ir.SourceMatchers},
+			{"target_matchers", ir.TargetMatchers},
+		} {
+			for _, m := range side.matchers {
+				if _, err := am.ParseMatcher(m); err != nil {
+					diags = append(diags, diag.Diagnostic{
+						Level:   diag.LevelError,
+						Code:    "AM_MATCHER_INVALID",
+						Message: fmt.Sprintf("inhibit_rules[%d].%s has invalid matcher %q: %v", i, side.field, m, err),
+					})
+				}
+			}
+		}
+	}
+
+	return diags
+}
+
+// validateRoute checks a route and its children; path names the route in
+// messages and src locates it when it was written in root_route.yaml.
+func (v AlertmanagerValidator) validateRoute(r am.Route, path string, src dsl.Source, receivers, intervals map[string]struct{}) []diag.Diagnostic {
+	var diags []diag.Diagnostic
+	fromDSL := r.Source != ""
+	if fromDSL {
+		src = dsl.Source{} // not written in root_route.yaml
+	}
+
+	if r.Receiver != "" {
+		_, channel := v.channels[r.Receiver]
+		// Unknown notify targets are reported by the flow and team validators.
+		if _, ok := receivers[r.Receiver]; !ok && (!fromDSL || channel) {
+			msg := fmt.Sprintf("%s references undefined receiver %q", path, r.Receiver)
+			if fromDSL {
+				msg = fmt.Sprintf("%s (%s) references receiver %q, which its channel did not produce", path, r.Source, r.Receiver)
+			}
+			diags = append(diags, src.KeyPos("receiver").Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_RECEIVER_UNDEFINED",
+				Message: msg,
+			}))
+		}
+	}
+
+	timeIntervals := []struct {
+		field string
+		names []string
+	}{
+		{"mute_time_intervals", r.MuteTimeIntervals},
+		{"active_time_intervals", r.ActiveTimeIntervals},
+	}
+	for _, ti := range timeIntervals {
+		for _, name := range ti.names {
+			_, window := v.windows[name]
+			// Unknown silence_when names are reported by the flow validator.
+			if _, ok := intervals[name]; !ok && (!fromDSL || window) {
+				diags = append(diags, src.KeyPos(ti.field).Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    "AM_TIME_INTERVAL_UNDEFINED",
+					Message: fmt.Sprintf("%s references undefined time interval %q", path, name),
+				}))
+			}
+		}
+	}
+
+	// Flow timing is checked by the flow validator.
+	durations := []struct {
+		field   string
+		value   string
+		nonZero bool
+	}{
+		{"group_wait", r.GroupWait, false},
+		{"group_interval", r.GroupInterval, true},
+		{"repeat_interval", r.RepeatInterval, true},
+	}
+	for _, d := range durations {
+		if d.value == "" || fromDSL {
+			continue
+		}
+		dur, err := am.ParseDuration(d.value)
+		if err != nil {
+			diags = append(diags, src.KeyPos(d.field).Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_DURATION_INVALID",
+				Message: fmt.Sprintf("%s.%s: %v", path, d.field, err),
+			}))
+			continue
+		}
+		if d.nonZero && dur == 0 {
+			diags = append(diags, src.KeyPos(d.field).Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_DURATION_ZERO",
+				Message: fmt.Sprintf("%s.%s cannot be zero", path, d.field),
+			}))
+		}
+	}
+
+	for _, m := range r.Matchers {
+		if _, err := am.ParseMatcher(m); err != nil {
+			diags = append(diags, src.KeyPos("matchers").Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "AM_MATCHER_INVALID",
+				Message: fmt.Sprintf("%s has invalid matcher %q: %v", path, m, err),
+			}))
+		}
+	}
+
+	// Child routes written in root_route.yaml are located at its routes key.
+	child := dsl.Source{Pos: src.KeyPos("routes")}
+	for i, c := range r.Routes {
+		diags = append(diags, v.validateRoute(c, fmt.Sprintf("%s.routes[%d]", path, i), child, receivers, intervals)...)
+	}
+
+	return diags
+}
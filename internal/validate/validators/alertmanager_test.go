@@ -0,0 +1,183 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/am"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/validate/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertmanagerValidator(t *testing.T) {
+	base := func() am.Config {
+		return am.Config{
+			Global:    map[string]any{"resolve_timeout": "5m"},
+			Receivers: []am.Receiver{{Name: "default"}, {Name: "payments-slack"}},
+			TimeIntervals: []am.TimeIntervalSet{
+				{Name: "night-shift"},
+			},
+			Route: am.Route{
+				Receiver:  "default",
+				GroupWait: "30s",
+				Routes: []am.Route{
+					{
+						Receiver:          "payments-slack",
+						Matchers:          []string{`severity="critical"`},
+						GroupInterval:     "5m",
+						RepeatInterval:    "1h",
+						MuteTimeIntervals: []string{"night-shift"},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(c *am.Config)
+		wantCodes []string
+	}{
+		{
+			name:      "valid config",
+			mutate:    func(c *am.Config) {},
+			wantCodes: nil,
+		},
+		{
+			name: "undefined receiver",
+			mutate: func(c *am.Config) {
+				c.Route.Routes[0].Receiver = "pager"
+			},
+			wantCodes: []string{"AM_RECEIVER_UNDEFINED"},
+		},
+		{
+			name: "undefined time interval",
+			mutate: func(c *am.Config) {
+				c.Route.Routes[0].MuteTimeIntervals = []string{"weekend"}
+			},
+			wantCodes: []string{"AM_TIME_INTERVAL_UNDEFINED"},
+		},
+		{
+			name: "bad durations",
+			mutate: func(c *am.Config) {
+				c.Global["resolve_timeout"] = "five minutes"
+				c.Route.GroupWait = "30"
+				c.Route.Routes[0].RepeatInterval = "0"
+			},
+			wantCodes: []string{"AM_DURATION_INVALID", "AM_DURATION_INVALID", "AM_DURATION_ZERO"},
+		},
+		{
+			name: "bad matcher",
+			mutate: func(c *am.Config) {
+				c.Route.Routes[0].Matchers = []string{`severity=~"("`}
+			},
+			wantCodes: []string{"AM_MATCHER_INVALID"},
+		},
+		{
+			name: "bad inhibit rule matcher",
+			mutate: func(c *am.Config) {
+				c.InhibitRules = []am.InhibitRule{{
+					SourceMatchers: []string{`severity="critical"`},
+					TargetMatchers: []string{`severity!~"("`},
+					Equal:          []string{"cluster"},
+				}}
+			},
+			wantCodes: []string{"AM_MATCHER_INVALID"},
+		},
+		{
+			name: "duplicate receivers across teams",
+			mutate: func(c *am.Config) {
+				c.Receivers = append(c.Receivers, am.Receiver{Name: "payments-slack"})
+			},
+			wantCodes: []string{"AM_RECEIVER_DUP"},
+		},
+		{
+			name: "root route rules",
+			mutate: func(c *am.Config) {
+				c.Route.Receiver = ""
+				c.Route.Matchers = []string{`team="payments"`}
+				c.Route.Continue = true
+			},
+			wantCodes: []string{"AM_ROOT_NO_RECEIVER", "AM_ROOT_MATCHERS", "AM_ROOT_CONTINUE"},
+		},
+		{
+			name: "nested routes are checked",
+			mutate: func(c *am.Config) {
+				c.Route.Routes[0].Routes = []am.Route{{Receiver: "nope", GroupInterval: "0"}}
+			},
+			wantCodes: []string{"AM_RECEIVER_UNDEFINED", "AM_DURATION_ZERO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base()
+			tt.mutate(&cfg)
+
+			diags := validators.NewAlertmanagerValidator(cfg, dsl.Project{}, "").Validate()
+
+			var gotCodes []string
+			for _, d := range diags {
+				gotCodes = append(gotCodes, d.Code)
+			}
+			assert.ElementsMatch(t, tt.wantCodes, gotCodes)
+		})
+	}
+}
+
+// Routes built from flows are checked, with positions, by the flow and team
+// validators; only what those cannot see is reported here.
+func TestAlertmanagerValidator_DSLRoutes(t *testing.T) {
+	proj := dsl.Project{Teams: []dsl.Team{{
+		Name:           "payments",
+		Channels:       []dsl.Channel{{Name: "slack"}, {Name: "empty"}},
+		SilenceWindows: []dsl.SilenceWindow{{Name: "nights", Enabled: true}},
+	}}}
+	const src = "team/payments flows[0]"
+
+	tests := []struct {
+		name      string
+		route     am.Route
+		wantCodes []string
+	}{
+		{
+			name:  "unknown notify target",
+			route: am.Route{Receiver: "payments/nope", Source: src},
+		},
+		{
+			name:      "channel without a receiver",
+			route:     am.Route{Receiver: "payments/empty", Source: src},
+			wantCodes: []string{"AM_RECEIVER_UNDEFINED"},
+		},
+		{
+			name:  "invalid timing",
+			route: am.Route{Receiver: "payments/slack", GroupWait: "30x", RepeatInterval: "0", Source: src},
+		},
+		{
+			name:  "unknown silence window",
+			route: am.Route{Receiver: "payments/slack", MuteTimeIntervals: []string{"missing"}, Source: src},
+		},
+		{
+			name:      "silence window without a time interval",
+			route:     am.Route{Receiver: "payments/slack", MuteTimeIntervals: []string{"payments/nights"}, Source: src},
+			wantCodes: []string{"AM_TIME_INTERVAL_UNDEFINED"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := am.Config{
+				Receivers: []am.Receiver{{Name: "default"}, {Name: "payments/slack"}},
+				Route:     am.Route{Receiver: "default", Routes: []am.Route{tt.route}},
+			}
+
+			diags := validators.NewAlertmanagerValidator(cfg, proj, "").Validate()
+
+			var gotCodes []string
+			for _, d := range diags {
+				gotCodes = append(gotCodes, d.Code)
+			}
+			assert.Equal(t, tt.wantCodes, gotCodes)
+		})
+	}
+}
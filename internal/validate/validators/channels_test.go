@@ -48,7 +48,7 @@ func TestValidateChannels(t *testing.T) {
 		{
 			name: "unknown type",
 			channels: []dsl.Channel{
-				{Name: "payments-slack", Type: "pagerduty"},
+				{Name: "payments-slack", Type: "carrier-pigeon"},
 			},
 			wantErrs: []string{"CHANNEL_UNKNOWN_TYPE"},
 		},
@@ -61,6 +61,53 @@ func TestValidateChannels(t *testing.T) {
 			},
 			wantErrs: nil,
 		},
+		{
+			name: "email without to",
+			channels: []dsl.Channel{
+				{Name: "payments-email", Type: "email", Configs: []map[string]any{{"from": "am@example.com"}}},
+			},
+			wantErrs: []string{"CHANNEL_EMAIL_NO_TO"},
+		},
+		{
+			name: "every receiver type with required fields",
+			channels: []dsl.Channel{
+				{Name: "email", Type: "email", Configs: []map[string]any{{"to": "oncall@example.com"}}},
+				{Name: "pagerduty", Type: "pagerduty", Configs: []map[string]any{{"routing_key": "${PD_KEY}"}}},
+				{Name: "pushover", Type: "pushover", Configs: []map[string]any{{"user_key_file": "/k", "token": "${PO_TOKEN}"}}},
+				{Name: "slack", Type: "slack", Configs: []map[string]any{{"channel": "#alerts"}}},
+				{Name: "sns", Type: "sns", Configs: []map[string]any{{"topic_arn": "arn:aws:sns:eu-west-1:1:alerts"}}},
+				{Name: "victorops", Type: "victorops", Configs: []map[string]any{{"routing_key": "payments"}}},
+				{Name: "webhook", Type: "webhook", Configs: []map[string]any{{"url": "${HOOK_URL}"}}},
+				{Name: "opsgenie", Type: "opsgenie", Configs: []map[string]any{{}}},
+				{Name: "wechat", Type: "wechat", Configs: []map[string]any{{}}},
+				{Name: "telegram", Type: "telegram", Configs: []map[string]any{{"chat_id": -1001, "bot_token": "${TG_TOKEN}"}}},
+				{Name: "webex", Type: "webex", Configs: []map[string]any{{"room_id": "abc"}}},
+				{Name: "discord", Type: "discord", Configs: []map[string]any{{"webhook_url": "${DISCORD_URL}"}}},
+				{Name: "msteams", Type: "msteams", Configs: []map[string]any{{"webhook_url": "${TEAMS_URL}"}}},
+				{Name: "msteamsv2", Type: "msteamsv2", Configs: []map[string]any{{"webhook_url_file": "/url"}}},
+				{Name: "jira", Type: "jira", Configs: []map[string]any{{"project": "OPS", "issue_type": "Bug"}}},
+				{Name: "rocketchat", Type: "rocketchat", Configs: []map[string]any{{}}},
+			},
+			wantErrs: nil,
+		},
+		{
+			name: "missing required fields",
+			channels: []dsl.Channel{
+				{Name: "pagerduty", Type: "pagerduty", Configs: []map[string]any{{"severity": "critical"}}},
+				{Name: "pushover", Type: "pushover", Configs: []map[string]any{{"user_key": ""}}},
+				{Name: "webhook", Type: "webhook", Configs: []map[string]any{{}}},
+				{Name: "telegram", Type: "telegram", Configs: []map[string]any{{"bot_token": "${TG_TOKEN}"}}},
+				{Name: "jira", Type: "jira", Configs: []map[string]any{{"project": "OPS"}}},
+			},
+			wantErrs: []string{
+				"CHANNEL_PAGERDUTY_NO_ROUTING_KEY",
+				"CHANNEL_PUSHOVER_NO_USER_KEY",
+				"CHANNEL_PUSHOVER_NO_TOKEN",
+				"CHANNEL_WEBHOOK_NO_URL",
+				"CHANNEL_TELEGRAM_NO_CHAT_ID",
+				"CHANNEL_JIRA_NO_ISSUE_TYPE",
+			},
+		},
 	}
 
 	for _, tt := range tests {
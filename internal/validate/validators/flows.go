@@ -4,98 +4,196 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strings"
 
+	"github.com/nyambati/fuse/internal/am"
 	"github.com/nyambati/fuse/internal/diag"
 	"github.com/nyambati/fuse/internal/dsl"
 )
 
 type FlowValidator struct {
-	teams []dsl.Team
+	project dsl.Project
 }
 
-func NewFlowValidator(teams []dsl.Team) *FlowValidator {
+func NewFlowValidator(proj dsl.Project) *FlowValidator {
 	return &FlowValidator{
-		teams: teams,
+		project: proj,
 	}
 }
 
 func (v *FlowValidator) Validate() []diag.Diagnostic {
 	var diags []diag.Diagnostic
-	for _, team := range v.teams {
-		diags = append(diags, ValidateFlows(team)...)
+	for _, team := range v.project.Teams {
+		diags = append(diags, ValidateFlows(v.project, team)...)
 	}
 	return diags
 }
 
-// validateFlows checks notify presence, channel existence, when block validity, and duplicates.
-func ValidateFlows(team dsl.Team) []diag.Diagnostic {
-	var diags []diag.Diagnostic
+// ValidateFlows checks notify presence, channel existence, when block validity,
+// timing, silence_when references and duplicates. Notify targets may be the
+// team's channels or global channels referenced as global:<name>. Sub-flows
+// are validated recursively and named by path in messages, e.g. flows[0].flows[1].
+func ValidateFlows(proj dsl.Project, team dsl.Team) []diag.Diagnostic {
+	return validateFlowList(proj, team, notifyTargets(team, proj.Channels), team.Flows, "flows", team.DefaultNotify)
+}
 
-	// Build a set of channels in this team
-	channelSet := make(map[string]struct{})
+// notifyTargets returns the set of valid notify targets for a team.
+func notifyTargets(team dsl.Team, globals []dsl.Channel) map[string]struct{} {
+	set := make(map[string]struct{}, len(team.Channels)+len(globals))
 	for _, ch := range team.Channels {
-		channelSet[ch.Name] = struct{}{}
+		set[ch.Name] = struct{}{}
+	}
+	for _, ch := range globals {
+		set[dsl.GlobalScope+":"+ch.Name] = struct{}{}
 	}
+	return set
+}
+
+// validateFlowList validates sibling flows; inherited holds the notify targets
+// of the enclosing flow, if any.
+func validateFlowList(proj dsl.Project, team dsl.Team, channelSet map[string]struct{}, flows []dsl.Flow, prefix string, inherited dsl.Targets) []diag.Diagnostic {
+	var diags []diag.Diagnostic
 
 	// Track seen (notify, when) combinations for duplicate detection
 	signatures := make(map[string]string)
 
-	for idx, flow := range team.Flows {
-		// 1. Missing notify
-		if len(flow.Notify) == 0 {
-			diags = append(diags, diag.Diagnostic{
+	for idx, flow := range flows {
+		path := fmt.Sprintf("%s[%d]", prefix, idx)
+
+		// 1. Missing notify (a flow may inherit it or leave it to sub-flows)
+		if len(flow.Notify) == 0 && len(inherited) == 0 && len(flow.Flows) == 0 {
+			diags = append(diags, flow.Pos.Locate(diag.Diagnostic{
 				Level:   diag.LevelError,
 				Code:    "FLOW_NOTIFY_EMPTY",
-				Message: fmt.Sprintf("flow in team %q has no notify target", team.Name),
-			})
+				Message: fmt.Sprintf("flow %s in team %q has no notify target", path, team.Name),
+				File:    team.Path,
+			}))
 		}
 
-		// 2. Non-existent notify channel
-
-		if _, ok := channelSet[flow.Notify]; !ok {
-			diags = append(diags, diag.Diagnostic{
-				Level:   diag.LevelError,
-				Code:    "FLOW_NOTIFY_UNKNOWN",
-				Message: fmt.Sprintf("flow in team %q references unknown channel %q", team.Name, flow.Notify),
-			})
+		// 2. Non-existent or repeated notify channels
+		seenTargets := make(map[string]struct{}, len(flow.Notify))
+		for _, target := range flow.Notify {
+			if _, ok := channelSet[target]; !ok {
+				diags = append(diags, flow.KeyPos("notify").Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    "FLOW_NOTIFY_UNKNOWN",
+					Message: fmt.Sprintf("flow %s in team %q references unknown channel %q", path, team.Name, target),
+					File:    team.Path,
+				}))
+			}
+			if _, dup := seenTargets[target]; dup {
+				diags = append(diags, flow.KeyPos("notify").Locate(diag.Diagnostic{
+					Level:   diag.LevelWarn,
+					Code:    "FLOW_NOTIFY_DUPLICATE",
+					Message: fmt.Sprintf("flow %s in team %q lists channel %q more than once", path, team.Name, target),
+					File:    team.Path,
+				}))
+			}
+			seenTargets[target] = struct{}{}
 		}
 
 		// 3. Empty or missing when
 		if len(flow.When) == 0 {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, flow.KeyPos("when").Locate(diag.Diagnostic{
 				Level:   diag.LevelError,
 				Code:    "FLOW_WHEN_EMPTY",
-				Message: fmt.Sprintf("flow in team %q has no conditions (when block is empty)", team.Name),
-			})
+				Message: fmt.Sprintf("flow %s in team %q has no conditions (when block is empty)", path, team.Name),
+				File:    team.Path,
+			}))
 		}
 
 		// 4. Invalid matcher syntax
 		for _, m := range flow.When {
 			if err := validateMatcher(m); err != nil {
-				diags = append(diags, diag.Diagnostic{
+				diags = append(diags, flow.KeyPos("when").Locate(diag.Diagnostic{
 					Level:   diag.LevelError,
 					Code:    "FLOW_MATCHER_INVALID",
-					Message: fmt.Sprintf("invalid matcher in flow (team %q): %v", team.Name, err),
-				})
+					Message: fmt.Sprintf("invalid matcher in flow %s (team %q): %v", path, team.Name, err),
+					File:    team.Path,
+				}))
 			}
 		}
 
 		// Create a signature for duplicate detection
 		sig := flowSignature(flow)
 		if prev, ok := signatures[sig]; ok {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, flow.Pos.Locate(diag.Diagnostic{
 				Level:   diag.LevelWarn,
 				Code:    "FLOW_DUPLICATE",
-				Message: fmt.Sprintf("duplicate flow matcher set and notify found for flow %s and %d", prev, idx+1),
-			})
+				Message: fmt.Sprintf("duplicate flow matcher set and notify found for %s and %s", prev, path),
+				File:    team.Path,
+			}))
 		} else {
-			signatures[sig] = fmt.Sprintf("flow %d", idx+1)
+			signatures[sig] = path
+		}
+
+		// 5. Timing
+		diags = append(diags, validateFlowTiming(team, flow, path)...)
+
+		// 6. Unknown silence windows (disabled ones are fine, they never mute)
+		for _, name := range flow.SilenceWhen {
+			if _, _, ok := proj.LookupWindow(team, name); !ok {
+				diags = append(diags, flow.KeyPos("silence_when").Locate(diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    "FLOW_SILENCE_UNKNOWN",
+					Message: fmt.Sprintf("flow %s in team %q references unknown silence window %q", path, team.Name, name),
+					File:    team.Path,
+				}))
+			}
+		}
+
+		// 7. Sub-flows
+		if len(flow.Flows) > 0 {
+			notify := inherited
+			if len(flow.Notify) > 0 {
+				notify = flow.Notify
+			}
+			diags = append(diags, validateFlowList(proj, team, channelSet, flow.Flows, path+".flows", notify)...)
 		}
 	}
 
 	return diags
 }
 
+// validateFlowTiming checks the durations a flow sets, with the rules
+// Alertmanager applies to the route fields they become.
+func validateFlowTiming(team dsl.Team, flow dsl.Flow, path string) []diag.Diagnostic {
+	var diags []diag.Diagnostic
+	durations := []struct {
+		key     string
+		value   string
+		nonZero bool
+	}{
+		{"wait_for", flow.WaitFor, false},
+		{"group_interval", flow.GroupInterval, true},
+		{"repeat_after", flow.RepeatAfter, true},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		dur, err := am.ParseDuration(d.value)
+		if err != nil {
+			diags = append(diags, flow.KeyPos(d.key).Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "FLOW_DURATION_INVALID",
+				Message: fmt.Sprintf("flow %s in team %q has invalid %s: %v", path, team.Name, d.key, err),
+				File:    team.Path,
+			}))
+			continue
+		}
+		if d.nonZero && dur == 0 {
+			diags = append(diags, flow.KeyPos(d.key).Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "FLOW_DURATION_ZERO",
+				Message: fmt.Sprintf("flow %s in team %q: %s cannot be zero", path, team.Name, d.key),
+				File:    team.Path,
+			}))
+		}
+	}
+	return diags
+}
+
 // validateMatcher checks that the matcher key/value is syntactically valid.
 // This is a placeholder — expand with proper Alertmanager matcher parsing.
 // validateMatcher checks syntax for our DSL `when` block matchers.
@@ -120,8 +218,13 @@ func validateMatcher(m dsl.Matcher) error {
 }
 
 func flowSignature(f dsl.Flow) string {
+	return fmt.Sprintf("notify=%s;when=%s", strings.Join(f.Notify, ","), matcherSignature(f.When))
+}
+
+// matcherSignature renders a matcher set in an order-independent form.
+func matcherSignature(ms []dsl.Matcher) string {
 	// Sort matchers for deterministic comparison
-	matcherCopy := append([]dsl.Matcher(nil), f.When...)
+	matcherCopy := append([]dsl.Matcher(nil), ms...)
 	sort.Slice(matcherCopy, func(i, j int) bool {
 		if matcherCopy[i].Label != matcherCopy[j].Label {
 			return matcherCopy[i].Label < matcherCopy[j].Label
@@ -137,5 +240,5 @@ func flowSignature(f dsl.Flow) string {
 		matcherStrs = append(matcherStrs, fmt.Sprintf("%s%s%s", m.Label, m.Op, m.Value))
 	}
 
-	return fmt.Sprintf("notify=%s;when=%v", f.Notify, matcherStrs)
+	return fmt.Sprintf("%v", matcherStrs)
 }
@@ -3,15 +3,19 @@ package validators_test
 import (
 	"testing"
 
+	"github.com/nyambati/fuse/internal/diag"
 	"github.com/nyambati/fuse/internal/dsl"
 	"github.com/nyambati/fuse/internal/validate/validators"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestValidateFlows(t *testing.T) {
 	tests := []struct {
 		name     string
 		team     dsl.Team
+		globals  []dsl.Channel
 		wantCode []string
 	}{
 		{
@@ -23,7 +27,7 @@ func TestValidateFlows(t *testing.T) {
 				},
 				Flows: []dsl.Flow{
 					{
-						Notify: "slack-payments",
+						Notify: dsl.Targets{"slack-payments"},
 						When: []dsl.Matcher{
 							{Label: "severity", Op: "=", Value: "critical"},
 						},
@@ -56,7 +60,7 @@ func TestValidateFlows(t *testing.T) {
 				Channels: []dsl.Channel{},
 				Flows: []dsl.Flow{
 					{
-						Notify: "non-existent",
+						Notify: dsl.Targets{"non-existent"},
 						When: []dsl.Matcher{
 							{Label: "severity", Op: "=", Value: "critical"},
 						},
@@ -65,6 +69,163 @@ func TestValidateFlows(t *testing.T) {
 			},
 			wantCode: []string{"FLOW_NOTIFY_UNKNOWN"},
 		},
+		{
+			name: "multiple notify targets",
+			team: dsl.Team{
+				Name: "payments",
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+					{Name: "pager"},
+				},
+				Flows: []dsl.Flow{
+					{
+						Notify: dsl.Targets{"slack", "pager"},
+						When: []dsl.Matcher{
+							{Label: "severity", Op: "=", Value: "critical"},
+						},
+					},
+				},
+			},
+			wantCode: nil,
+		},
+		{
+			name: "unknown target among several",
+			team: dsl.Team{
+				Name: "payments",
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+				},
+				Flows: []dsl.Flow{
+					{
+						Notify: dsl.Targets{"slack", "pager"},
+						When: []dsl.Matcher{
+							{Label: "severity", Op: "=", Value: "critical"},
+						},
+					},
+				},
+			},
+			wantCode: []string{"FLOW_NOTIFY_UNKNOWN"},
+		},
+		{
+			name: "repeated notify target",
+			team: dsl.Team{
+				Name: "payments",
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+				},
+				Flows: []dsl.Flow{
+					{
+						Notify: dsl.Targets{"slack", "slack"},
+						When: []dsl.Matcher{
+							{Label: "severity", Op: "=", Value: "critical"},
+						},
+					},
+				},
+			},
+			wantCode: []string{"FLOW_NOTIFY_DUPLICATE"},
+		},
+		{
+			name: "nested sub-flows inherit notify",
+			team: dsl.Team{
+				Name: "payments",
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+					{Name: "pager"},
+				},
+				Flows: []dsl.Flow{
+					{
+						Notify: dsl.Targets{"slack"},
+						When:   []dsl.Matcher{{Label: "team", Op: "=", Value: "payments"}},
+						Flows: []dsl.Flow{
+							{
+								When: []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+								Flows: []dsl.Flow{
+									{
+										Notify: dsl.Targets{"pager"},
+										When:   []dsl.Matcher{{Label: "env", Op: "=", Value: "prod"}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantCode: nil,
+		},
+		{
+			name: "nested sub-flow errors",
+			team: dsl.Team{
+				Name: "payments",
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+				},
+				Flows: []dsl.Flow{
+					{
+						When: []dsl.Matcher{{Label: "team", Op: "=", Value: "payments"}},
+						Flows: []dsl.Flow{
+							{
+								When: []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+							},
+							{
+								Notify: dsl.Targets{"pager"},
+								When:   []dsl.Matcher{{Label: "env", Op: "=~", Value: "("}},
+							},
+						},
+					},
+				},
+			},
+			wantCode: []string{"FLOW_NOTIFY_EMPTY", "FLOW_NOTIFY_UNKNOWN", "FLOW_MATCHER_INVALID"},
+		},
+		{
+			name: "flow inherits team default notify",
+			team: dsl.Team{
+				Name:          "payments",
+				DefaultNotify: dsl.Targets{"slack"},
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+				},
+				Flows: []dsl.Flow{
+					{
+						When: []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+					},
+				},
+			},
+			wantCode: nil,
+		},
+		{
+			name: "global channel reference",
+			team: dsl.Team{
+				Name:          "payments",
+				DefaultNotify: dsl.Targets{"global:catch-all"},
+				Channels: []dsl.Channel{
+					{Name: "slack"},
+				},
+				Flows: []dsl.Flow{
+					{
+						Notify: dsl.Targets{"slack", "global:sre-pager"},
+						When:   []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+					},
+				},
+			},
+			globals:  []dsl.Channel{{Name: "sre-pager"}, {Name: "catch-all"}},
+			wantCode: nil,
+		},
+		{
+			name: "unknown global channel",
+			team: dsl.Team{
+				Name: "payments",
+				Channels: []dsl.Channel{
+					{Name: "sre-pager"},
+				},
+				Flows: []dsl.Flow{
+					{
+						Notify: dsl.Targets{"global:sre-pager"},
+						When:   []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+					},
+				},
+			},
+			wantCode: []string{"FLOW_NOTIFY_UNKNOWN"},
+		},
 		{
 			name: "empty when block",
 			team: dsl.Team{
@@ -74,7 +235,7 @@ func TestValidateFlows(t *testing.T) {
 				},
 				Flows: []dsl.Flow{
 					{
-						Notify: "slack-team3",
+						Notify: dsl.Targets{"slack-team3"},
 					},
 				},
 			},
@@ -89,7 +250,7 @@ func TestValidateFlows(t *testing.T) {
 				},
 				Flows: []dsl.Flow{
 					{
-						Notify: "slack-team4",
+						Notify: dsl.Targets{"slack-team4"},
 						When: []dsl.Matcher{
 							{Label: "severity", Op: "badop", Value: "critical"},
 						},
@@ -107,7 +268,7 @@ func TestValidateFlows(t *testing.T) {
 				},
 				Flows: []dsl.Flow{
 					{
-						Notify: "slack-team5",
+						Notify: dsl.Targets{"slack-team5"},
 						When: []dsl.Matcher{
 							{Label: "instance", Op: "=~", Value: "("}, // invalid regex
 						},
@@ -125,13 +286,13 @@ func TestValidateFlows(t *testing.T) {
 				},
 				Flows: []dsl.Flow{
 					{
-						Notify: "slack-team6",
+						Notify: dsl.Targets{"slack-team6"},
 						When: []dsl.Matcher{
 							{Label: "severity", Op: "=", Value: "critical"},
 						},
 					},
 					{
-						Notify: "slack-team6",
+						Notify: dsl.Targets{"slack-team6"},
 						When: []dsl.Matcher{
 							{Label: "severity", Op: "=", Value: "critical"},
 						},
@@ -140,11 +301,58 @@ func TestValidateFlows(t *testing.T) {
 			},
 			wantCode: []string{"FLOW_DUPLICATE"},
 		},
+		{
+			name: "invalid timing",
+			team: dsl.Team{
+				Name:     "payments",
+				Channels: []dsl.Channel{{Name: "slack"}},
+				Flows: []dsl.Flow{{
+					Notify:      dsl.Targets{"slack"},
+					When:        []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+					WaitFor:     "30x",
+					RepeatAfter: "0s",
+				}},
+			},
+			wantCode: []string{"FLOW_DURATION_INVALID", "FLOW_DURATION_ZERO"},
+		},
+		{
+			name: "unknown silence window in a sub-flow",
+			team: dsl.Team{
+				Name:     "payments",
+				Channels: []dsl.Channel{{Name: "slack"}},
+				Flows: []dsl.Flow{{
+					Notify: dsl.Targets{"slack"},
+					When:   []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+					Flows: []dsl.Flow{{
+						When:        []dsl.Matcher{{Label: "env", Op: "=", Value: "prod"}},
+						SilenceWhen: []string{"missing"},
+					}},
+				}},
+			},
+			wantCode: []string{"FLOW_SILENCE_UNKNOWN"},
+		},
+		{
+			name: "known and disabled silence windows",
+			team: dsl.Team{
+				Name:     "payments",
+				Channels: []dsl.Channel{{Name: "slack"}},
+				SilenceWindows: []dsl.SilenceWindow{
+					{Name: "nights", Enabled: true},
+					{Name: "lunch"},
+				},
+				Flows: []dsl.Flow{{
+					Notify:      dsl.Targets{"slack"},
+					When:        []dsl.Matcher{{Label: "severity", Op: "=", Value: "critical"}},
+					SilenceWhen: []string{"nights", "lunch"},
+				}},
+			},
+			wantCode: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			diags := validators.ValidateFlows(tt.team)
+			diags := validators.ValidateFlows(dsl.Project{Channels: tt.globals}, tt.team)
 			var codes []string
 			for _, d := range diags {
 				codes = append(codes, d.Code)
@@ -158,3 +366,50 @@ func TestValidateFlows(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateFlows_Positions(t *testing.T) {
+	var file struct {
+		Flows []dsl.Flow `yaml:"flows"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(`flows:
+  - when: {severity: critical}
+    notify: pagr
+`), &file))
+
+	team := dsl.Team{Name: "payments", Path: "teams/payments", Flows: file.Flows}
+	diags := validators.ValidateFlows(dsl.Project{}, team)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Diagnostic{
+		Level:   diag.LevelError,
+		Code:    "FLOW_NOTIFY_UNKNOWN",
+		Message: `flow flows[0] in team "payments" references unknown channel "pagr"`,
+		File:    "teams/payments",
+		Line:    3,
+		Column:  5,
+	}, diags[0])
+}
+
+func TestValidateFlows_TimingAndSilencePositions(t *testing.T) {
+	var file struct {
+		Flows []dsl.Flow `yaml:"flows"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(`flows:
+  - when: {severity: critical}
+    notify: slack
+    wait_for: 30x
+    silence_when: [missing]
+`), &file))
+
+	team := dsl.Team{
+		Name:     "payments",
+		Path:     "teams/payments",
+		Channels: []dsl.Channel{{Name: "slack"}},
+		Flows:    file.Flows,
+	}
+	diags := validators.ValidateFlows(dsl.Project{}, team)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "FLOW_DURATION_INVALID", diags[0].Code)
+	assert.Equal(t, [2]int{4, 5}, [2]int{diags[0].Line, diags[0].Column})
+	assert.Equal(t, "FLOW_SILENCE_UNKNOWN", diags[1].Code)
+	assert.Equal(t, [2]int{5, 5}, [2]int{diags[1].Line, diags[1].Column})
+}
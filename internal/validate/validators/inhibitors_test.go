@@ -19,14 +19,10 @@ func TestInhibitorValidator(t *testing.T) {
 			proj: dsl.Project{
 				Inhibitors: []dsl.Inhibitor{
 					{
-						Name: "suppress-warnings-when-critical",
-						If: map[string]string{
-							"severity": "critical",
-						},
-						Suppress: map[string]string{
-							"severity": "warning",
-						},
-						When: []string{"alertname", "cluster"},
+						Name:     "suppress-warnings-when-critical",
+						If:       dsl.Matchers{{Label: "severity", Op: "=", Value: "critical"}},
+						Suppress: dsl.Matchers{{Label: "severity", Op: "=", Value: "warning"}},
+						When:     []string{"alertname", "cluster"},
 					},
 				},
 				Teams: []dsl.Team{
@@ -34,12 +30,10 @@ func TestInhibitorValidator(t *testing.T) {
 						Name: "payments",
 						Inhibitors: []dsl.Inhibitor{
 							{
-								Name: "team-inh",
-								If:   map[string]string{"team": "payments"},
-								Suppress: map[string]string{
-									"team": "billing",
-								},
-								When: []string{"cluster"},
+								Name:     "team-inh",
+								If:       dsl.Matchers{{Label: "team", Op: "=", Value: "payments"}},
+								Suppress: dsl.Matchers{{Label: "team", Op: "=", Value: "billing"}},
+								When:     []string{"cluster"},
 							},
 						},
 					},
@@ -53,8 +47,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "",
-						If:       map[string]string{"severity": "critical"},
-						Suppress: map[string]string{"severity": "warning"},
+						If:       dsl.Matchers{{Label: "severity", Op: "=", Value: "critical"}},
+						Suppress: dsl.Matchers{{Label: "severity", Op: "=", Value: "warning"}},
 						When:     []string{"cluster"},
 					},
 				},
@@ -67,14 +61,14 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "dup",
-						If:       map[string]string{"a": "b"},
-						Suppress: map[string]string{"a": "c"},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "c"}},
 						When:     []string{"x"},
 					},
 					{
 						Name:     "dup",
-						If:       map[string]string{"a": "b"},
-						Suppress: map[string]string{"a": "c"},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "c"}},
 						When:     []string{"x"},
 					},
 				},
@@ -87,8 +81,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "shadowed",
-						If:       map[string]string{"a": "b"},
-						Suppress: map[string]string{"a": "c"},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "c"}},
 						When:     []string{"x"},
 					},
 				},
@@ -98,8 +92,8 @@ func TestInhibitorValidator(t *testing.T) {
 						Inhibitors: []dsl.Inhibitor{
 							{
 								Name:     "shadowed",
-								If:       map[string]string{"a": "b"},
-								Suppress: map[string]string{"a": "c"},
+								If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+								Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "c"}},
 								When:     []string{"x"},
 							},
 						},
@@ -114,8 +108,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "no-if",
-						If:       map[string]string{},
-						Suppress: map[string]string{"a": "b"},
+						If:       dsl.Matchers{},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
 						When:     []string{"x"},
 					},
 				},
@@ -128,8 +122,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "no-suppress",
-						If:       map[string]string{"a": "b"},
-						Suppress: map[string]string{},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						Suppress: dsl.Matchers{},
 						When:     []string{"x"},
 					},
 				},
@@ -142,8 +136,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "no-when",
-						If:       map[string]string{"a": "b"},
-						Suppress: map[string]string{"a": "b"},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
 						When:     []string{},
 					},
 				},
@@ -156,8 +150,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "dup-when",
-						If:       map[string]string{"a": "b"},
-						Suppress: map[string]string{"a": "b"},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
 						When:     []string{"x", "x"},
 					},
 				},
@@ -170,8 +164,8 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "empty-key",
-						If:       map[string]string{"": "val"},
-						Suppress: map[string]string{"a": "b"},
+						If:       dsl.Matchers{{Label: "", Op: "=", Value: "val"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
 						When:     []string{"x"},
 					},
 				},
@@ -184,14 +178,59 @@ func TestInhibitorValidator(t *testing.T) {
 				Inhibitors: []dsl.Inhibitor{
 					{
 						Name:     "empty-value",
-						If:       map[string]string{"a": ""},
-						Suppress: map[string]string{"a": "b"},
+						If:       dsl.Matchers{{Label: "a", Op: "=", Value: ""}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
 						When:     []string{"x"},
 					},
 				},
 			},
 			expect: []string{"MATCH_EMPTY_VALUE"},
 		},
+		{
+			name: "regex and negative matchers",
+			proj: dsl.Project{
+				Inhibitors: []dsl.Inhibitor{
+					{
+						Name: "regex",
+						If: dsl.Matchers{
+							{Label: "severity", Op: "=~", Value: "critical|page"},
+							{Label: "env", Op: "!=", Value: "staging"},
+						},
+						Suppress: dsl.Matchers{{Label: "severity", Op: "!~", Value: "critical|page"}},
+						When:     []string{"cluster"},
+					},
+				},
+			},
+			expect: nil,
+		},
+		{
+			name: "invalid regex",
+			proj: dsl.Project{
+				Inhibitors: []dsl.Inhibitor{
+					{
+						Name:     "bad-regex",
+						If:       dsl.Matchers{{Label: "severity", Op: "=~", Value: "("}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "b"}},
+						When:     []string{"x"},
+					},
+				},
+			},
+			expect: []string{"MATCH_REGEX_INVALID"},
+		},
+		{
+			name: "invalid operator",
+			proj: dsl.Project{
+				Inhibitors: []dsl.Inhibitor{
+					{
+						Name:     "bad-op",
+						If:       dsl.Matchers{{Label: "a", Op: "==", Value: "b"}},
+						Suppress: dsl.Matchers{{Label: "a", Op: "=", Value: "c"}},
+						When:     []string{"x"},
+					},
+				},
+			},
+			expect: []string{"MATCH_OP_INVALID"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +243,9 @@ func TestInhibitorValidator(t *testing.T) {
 			for _, exp := range tt.expect {
 				assert.Contains(t, gotCodes, exp, "expected code %s not found", exp)
 			}
+			if tt.expect == nil {
+				assert.Empty(t, diags, "expected no diagnostics")
+			}
 		})
 	}
 }
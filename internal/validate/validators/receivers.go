@@ -0,0 +1,66 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+)
+
+// ReceiverNamesValidator checks that the receiver names derived from team
+// channels are unique across teams under the configured name pattern.
+type ReceiverNamesValidator struct {
+	teams   []dsl.Team
+	pattern string
+}
+
+// NewReceiverNamesValidator creates a validator for the receivers.name_pattern
+// setting of .fuse.yaml; an empty pattern means dsl.DefaultReceiverPattern.
+func NewReceiverNamesValidator(teams []dsl.Team, pattern string) Validator {
+	return ReceiverNamesValidator{teams: teams, pattern: pattern}
+}
+
+func (v ReceiverNamesValidator) Validate() []diag.Diagnostic {
+	var diags []diag.Diagnostic
+
+	if v.pattern != "" && !strings.Contains(v.pattern, "{channel}") {
+		diags = append(diags, diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "RECEIVER_PATTERN_NO_CHANNEL",
+			Message: fmt.Sprintf("receivers.name_pattern %q must contain {channel}", v.pattern),
+		})
+		return diags
+	}
+
+	type owner struct{ team, channel string }
+	seen := map[string]owner{}
+
+	for _, t := range v.teams {
+		for _, ch := range t.Channels {
+			name := strings.TrimSpace(ch.Name)
+			if name == "" {
+				continue
+			}
+			recv := dsl.ReceiverName(v.pattern, t.Name, name)
+			prev, ok := seen[recv]
+			if !ok {
+				seen[recv] = owner{team: t.Name, channel: name}
+				continue
+			}
+			// Duplicates within a team are reported as CHANNEL_DUP_NAME.
+			if prev.team == t.Name {
+				continue
+			}
+			diags = append(diags, ch.KeyPos("name").Locate(diag.Diagnostic{
+				Level: diag.LevelError,
+				Code:  "RECEIVER_NAME_COLLISION",
+				Message: fmt.Sprintf("receiver %q of team %q channel %q collides with team %q channel %q; include {team} in receivers.name_pattern or rename a channel",
+					recv, t.Name, name, prev.team, prev.channel),
+				File: t.Path,
+			}))
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,38 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/validate/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiverNamesValidator(t *testing.T) {
+	teams := []dsl.Team{
+		{Name: "payments", Channels: []dsl.Channel{{Name: "slack"}, {Name: "pager"}}},
+		{Name: "billing", Channels: []dsl.Channel{{Name: "slack"}}},
+	}
+
+	tests := []struct {
+		name      string
+		pattern   string
+		wantCodes []string
+	}{
+		{name: "default pattern namespaces by team", pattern: "", wantCodes: nil},
+		{name: "custom pattern with team", pattern: "{team}-{channel}", wantCodes: nil},
+		{name: "namespacing off", pattern: "{channel}", wantCodes: []string{"RECEIVER_NAME_COLLISION"}},
+		{name: "pattern without channel", pattern: "{team}", wantCodes: []string{"RECEIVER_PATTERN_NO_CHANNEL"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validators.NewReceiverNamesValidator(teams, tt.pattern).Validate()
+			var codes []string
+			for _, d := range diags {
+				codes = append(codes, d.Code)
+			}
+			assert.Equal(t, tt.wantCodes, codes)
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package validators
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nyambati/fuse/internal/channels"
+	"github.com/nyambati/fuse/internal/config"
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/secrets"
+)
+
+// credentialFields are config keys that hold credentials in channels of a type
+// the registry does not know, at any depth. Registered types declare theirs
+// with the Secret and SecretKeys flags of their fields.
+var credentialFields = map[string]struct{}{
+	"api_url":       {},
+	"webhook_url":   {},
+	"api_key":       {},
+	"api_secret":    {},
+	"routing_key":   {},
+	"service_key":   {},
+	"auth_password": {},
+	"auth_secret":   {},
+	"bot_token":     {},
+	"token":         {},
+	"user_key":      {},
+	"password":      {},
+	"credentials":   {},
+	"bearer_token":  {},
+}
+
+// tokenFormats are well-known credential formats that should never appear in plain text.
+var tokenFormats = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	{"Slack webhook URL", regexp.MustCompile(`https://hooks\.slack\.com/(services|workflows)/T[A-Z0-9]+/[A-Z0-9]+/[A-Za-z0-9]+`)},
+	{"Slack token", regexp.MustCompile(`xox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"Discord webhook URL", regexp.MustCompile(`https://(discord|discordapp)\.com/api/webhooks/\d+/[\w-]+`)},
+	{"Microsoft Teams webhook URL", regexp.MustCompile(`https://[\w-]+\.webhook\.office\.com/webhookb2/\S+`)},
+	{"Telegram bot token", regexp.MustCompile(`\b\d{8,10}:[A-Za-z0-9_-]{35}\b`)},
+	{"AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[A-Z0-9]{16}\b`)},
+	{"GitHub token", regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`)},
+	{"private key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+}
+
+// fieldFormats are credential formats that are only meaningful for specific keys.
+var fieldFormats = map[string][]struct {
+	name string
+	re   *regexp.Regexp
+}{
+	"api_key":     {{"Opsgenie API key", regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)}},
+	"routing_key": {{"PagerDuty integration key", regexp.MustCompile(`^[0-9a-zA-Z]{32}$`)}},
+	"service_key": {{"PagerDuty integration key", regexp.MustCompile(`^[0-9a-zA-Z]{32}$`)}},
+}
+
+const (
+	entropyMinLen    = 20
+	entropyThreshold = 4.0
+)
+
+// SecretLeakValidator flags credentials written in plain text in channel configs.
+type SecretLeakValidator struct {
+	teams     []dsl.Team
+	allowlist []config.SecretAllow
+}
+
+// NewSecretLeakValidator creates a validator; findings matching an allowlist
+// entry are reported at INFO level instead.
+func NewSecretLeakValidator(teams []dsl.Team, allowlist []config.SecretAllow) Validator {
+	return SecretLeakValidator{teams: teams, allowlist: allowlist}
+}
+
+func (v SecretLeakValidator) Validate() []diag.Diagnostic {
+	var diags []diag.Diagnostic
+	for _, team := range v.teams {
+		for _, ch := range team.Channels {
+			for i, cfg := range ch.Configs {
+				keys := make([]string, 0, len(cfg))
+				for k := range cfg {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				for _, k := range keys {
+					field := fmt.Sprintf("configs[%d].%s", i, k)
+					diags = append(diags, v.scan(team, ch, ch.ConfigPos(i, k), field, k, k, cfg[k])...)
+				}
+			}
+		}
+	}
+	return diags
+}
+
+// scan walks a config value; key is the map key the value was found under,
+// top the top-level config key it belongs to and pos the position of top.
+func (v SecretLeakValidator) scan(team dsl.Team, ch dsl.Channel, pos dsl.Pos, field, top, key string, value any) []diag.Diagnostic {
+	var diags []diag.Diagnostic
+
+	switch t := value.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			diags = append(diags, v.scan(team, ch, pos, field+"."+k, top, k, t[k])...)
+		}
+	case []any:
+		for i, item := range t {
+			diags = append(diags, v.scan(team, ch, pos, fmt.Sprintf("%s[%d]", field, i), top, key, item)...)
+		}
+	case string:
+		if d, ok := v.check(team, ch, field, top, key, t); ok {
+			diags = append(diags, pos.Locate(d))
+		}
+	}
+
+	return diags
+}
+
+// isCredential reports whether key, found under the top-level config key top,
+// holds a credential in a config of ch.
+func isCredential(ch dsl.Channel, top, key string) bool {
+	t, ok := channels.Lookup(ch.Type)
+	if !ok {
+		_, ok := credentialFields[key]
+		return ok
+	}
+	if key == top {
+		return t.IsSecret(key)
+	}
+	return t.IsSecretKey(top, key)
+}
+
+// check returns at most one finding for a string value, the most severe one.
+// Placeholders are resolved at build time, so only the text around them and
+// their :- defaults are scanned.
+func (v SecretLeakValidator) check(team dsl.Team, ch dsl.Channel, field, top, key, value string) (diag.Diagnostic, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasSuffix(key, "_file") {
+		return diag.Diagnostic{}, false
+	}
+
+	literals, defaults := secrets.LiteralParts(value)
+	parts := append(literals, defaults...)
+	templated := secrets.HasPlaceholders(value)
+
+	where := fmt.Sprintf("team %q channel %q field %s", team.Name, ch.Name, field)
+
+	var d diag.Diagnostic
+	if name, part := matchTokenFormat(key, parts); name != "" {
+		d = diag.Diagnostic{
+			Level:   diag.LevelError,
+			Code:    "SECRET_LEAK",
+			Message: fmt.Sprintf("%s looks like a %s (%s); use a ${...} placeholder without a default", where, name, preview(part)),
+		}
+	} else if isCredential(ch, top, key) && !templated {
+		d = diag.Diagnostic{
+			Level:   diag.LevelWarn,
+			Code:    "SECRET_LITERAL",
+			Message: fmt.Sprintf("%s holds a credential in plain text (%s); use a ${...} placeholder or %s_file", where, preview(value), key),
+		}
+	} else if isCredential(ch, top, key) && len(defaults) > 0 {
+		d = diag.Diagnostic{
+			Level:   diag.LevelWarn,
+			Code:    "SECRET_LITERAL",
+			Message: fmt.Sprintf("%s holds a credential in a placeholder default (%s); drop the :- default", where, preview(defaults[0])),
+		}
+	} else if part, ok := highEntropyPart(parts); ok {
+		d = diag.Diagnostic{
+			Level:   diag.LevelWarn,
+			Code:    "SECRET_HIGH_ENTROPY",
+			Message: fmt.Sprintf("%s contains a high-entropy string (%s) that may be a secret", where, preview(part)),
+		}
+	} else {
+		return diag.Diagnostic{}, false
+	}
+	d.File = team.Path
+
+	if allow, ok := v.allowed(team.Name, ch.Name, field, key); ok {
+		d.Level = diag.LevelInfo
+		d.Message += " [allowlisted"
+		if allow.Reason != "" {
+			d.Message += ": " + allow.Reason
+		}
+		d.Message += "]"
+	}
+
+	return d, true
+}
+
+func (v SecretLeakValidator) allowed(team, channel, field, key string) (config.SecretAllow, bool) {
+	for _, a := range v.allowlist {
+		if a.Team != "" && a.Team != team {
+			continue
+		}
+		if a.Channel != "" && a.Channel != channel {
+			continue
+		}
+		if a.Field != "" && a.Field != field && a.Field != key {
+			continue
+		}
+		return a, true
+	}
+	return config.SecretAllow{}, false
+}
+
+// matchTokenFormat returns the name of the first credential format one of
+// parts matches, and that part.
+func matchTokenFormat(key string, parts []string) (string, string) {
+	for _, part := range parts {
+		for _, f := range tokenFormats {
+			if f.re.MatchString(part) {
+				return f.name, part
+			}
+		}
+		for _, f := range fieldFormats[key] {
+			if f.re.MatchString(part) {
+				return f.name, part
+			}
+		}
+	}
+	return "", ""
+}
+
+// highEntropyPart returns the first of parts that looks like a random token.
+func highEntropyPart(parts []string) (string, bool) {
+	for _, part := range parts {
+		if highEntropy(part) {
+			return part, true
+		}
+	}
+	return "", false
+}
+
+// highEntropy reports whether any URL/path segment of s looks like a random token.
+func highEntropy(s string) bool {
+	segments := strings.FieldsFunc(s, func(r rune) bool {
+		return strings.ContainsRune("/?&=:.@,; \t", r)
+	})
+	for _, seg := range segments {
+		if len(seg) < entropyMinLen {
+			continue
+		}
+		if !strings.ContainsAny(seg, "0123456789") {
+			continue // words and slugs are not tokens
+		}
+		if shannonEntropy(seg) >= entropyThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// preview shows just enough of a value to locate it without repeating the secret.
+func preview(s string) string {
+	if len(s) <= 8 {
+		return "***"
+	}
+	return s[:4] + "***"
+}
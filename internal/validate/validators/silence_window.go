@@ -33,12 +33,12 @@ func (v SilenceWindowsValidator) Validate() []diag.Diagnostic {
 		teamNames := map[string]struct{}{}
 		for _, sw := range t.SilenceWindows {
 			if _, exists := globalNames[sw.Name]; exists && strings.TrimSpace(sw.Name) != "" {
-				diags = append(diags, diag.Diagnostic{
+				diags = append(diags, sw.KeyPos("name").Locate(diag.Diagnostic{
 					Level:   diag.LevelWarn,
 					Code:    "SILENCE_NAME_SHADOW",
 					Message: fmt.Sprintf("team %q silence window %q shadows a global silence window", t.Name, sw.Name),
 					File:    t.Path,
-				})
+				}))
 			}
 			diags = append(diags, validateOneSilenceWindow(sw, t.Name, teamNames)...)
 		}
@@ -52,28 +52,28 @@ func validateOneSilenceWindow(sw dsl.SilenceWindow, scope string, seen map[strin
 
 	name := strings.TrimSpace(sw.Name)
 	if name == "" {
-		diags = append(diags, diag.Diagnostic{
+		diags = append(diags, sw.Pos.Locate(diag.Diagnostic{
 			Level:   diag.LevelError,
 			Code:    "SILENCE_NO_NAME",
 			Message: fmt.Sprintf("silence window in %s has no name", scope),
-		})
+		}))
 	} else {
 		if _, exists := seen[name]; exists {
-			diags = append(diags, diag.Diagnostic{
+			diags = append(diags, sw.KeyPos("name").Locate(diag.Diagnostic{
 				Level:   diag.LevelError,
 				Code:    "SILENCE_DUP_NAME",
 				Message: fmt.Sprintf("duplicate silence window %q in %s", name, scope),
-			})
+			}))
 		}
 		seen[name] = struct{}{}
 	}
 
 	if strings.TrimSpace(sw.Time) == "" {
-		diags = append(diags, diag.Diagnostic{
+		diags = append(diags, sw.Pos.Locate(diag.Diagnostic{
 			Level:   diag.LevelError,
 			Code:    "SILENCE_NO_TIME",
 			Message: fmt.Sprintf("silence window %q in %s has no time", name, scope),
-		})
+		}))
 	}
 
 	return diags
@@ -8,13 +8,15 @@ import (
 )
 
 type TeamValidator struct {
-	teams []dsl.Team
+	teams   []dsl.Team
+	globals []dsl.Channel
 }
 
 func (v TeamValidator) Validate() []diag.Diagnostic {
 	var diags []diag.Diagnostic
 
 	seen := map[string]struct{}{}
+	owners := map[string]string{} // ownership signature -> team name
 
 	for _, t := range v.teams {
 		if t.Name == "" {
@@ -35,11 +37,55 @@ func (v TeamValidator) Validate() []diag.Diagnostic {
 			})
 		}
 		seen[t.Name] = struct{}{}
+
+		diags = append(diags, validateTeamRoute(t, v.globals)...)
+
+		sig := matcherSignature(t.OwnershipMatchers())
+		if prev, ok := owners[sig]; ok {
+			diags = append(diags, t.TeamFile.KeyPos("ownership").Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "TEAM_OWNERSHIP_DUP",
+				Message: fmt.Sprintf("team %q has the same ownership matchers as team %q; its routes would never be reached", t.Name, prev),
+				File:    t.Path,
+			}))
+		} else {
+			owners[sig] = t.Name
+		}
+	}
+
+	return diags
+}
+
+// validateTeamRoute checks the settings of the team's parent route (team.yaml).
+func validateTeamRoute(t dsl.Team, globals []dsl.Channel) []diag.Diagnostic {
+	var diags []diag.Diagnostic
+
+	for _, m := range t.OwnershipMatchers() {
+		if err := validateMatcher(m); err != nil {
+			diags = append(diags, t.TeamFile.KeyPos("ownership").Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "TEAM_OWNERSHIP_INVALID",
+				Message: fmt.Sprintf("invalid ownership matcher for team %q: %v", t.Name, err),
+				File:    t.Path,
+			}))
+		}
+	}
+
+	channels := notifyTargets(t, globals)
+	for _, target := range t.DefaultNotify {
+		if _, ok := channels[target]; !ok {
+			diags = append(diags, t.TeamFile.KeyPos("default_notify").Locate(diag.Diagnostic{
+				Level:   diag.LevelError,
+				Code:    "TEAM_DEFAULT_NOTIFY_UNKNOWN",
+				Message: fmt.Sprintf("team %q default_notify references unknown channel %q", t.Name, target),
+				File:    t.Path,
+			}))
+		}
 	}
 
 	return diags
 }
 
-func NewTeamValidator(teams []dsl.Team) Validator {
-	return TeamValidator{teams: teams}
+func NewTeamValidator(teams []dsl.Team, globals []dsl.Channel) Validator {
+	return TeamValidator{teams: teams, globals: globals}
 }
@@ -0,0 +1,84 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/validate/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		teams     []dsl.Team
+		globals   []dsl.Channel
+		wantCodes []string
+	}{
+		{
+			name: "default ownership and default receiver",
+			teams: []dsl.Team{
+				{Name: "payments", DefaultNotify: dsl.Targets{"slack"}, Channels: []dsl.Channel{{Name: "slack"}}},
+				{Name: "billing"},
+			},
+			wantCodes: nil,
+		},
+		{
+			name: "custom ownership label",
+			teams: []dsl.Team{
+				{Name: "payments", Ownership: dsl.Matchers{{Label: "squad", Op: "=~", Value: "pay|billing"}}},
+				{Name: "billing"},
+			},
+			wantCodes: nil,
+		},
+		{
+			name: "duplicate team name",
+			teams: []dsl.Team{
+				{Name: "payments"},
+				{Name: "payments"},
+			},
+			wantCodes: []string{"TEAM_NAME_DUP", "TEAM_OWNERSHIP_DUP"},
+		},
+		{
+			name: "same ownership on two teams",
+			teams: []dsl.Team{
+				{Name: "payments", Ownership: dsl.Matchers{{Label: "squad", Op: "=", Value: "pay"}}},
+				{Name: "billing", Ownership: dsl.Matchers{{Label: "squad", Op: "=", Value: "pay"}}},
+			},
+			wantCodes: []string{"TEAM_OWNERSHIP_DUP"},
+		},
+		{
+			name: "invalid ownership matcher",
+			teams: []dsl.Team{
+				{Name: "payments", Ownership: dsl.Matchers{{Label: "squad", Op: "=~", Value: "("}}},
+			},
+			wantCodes: []string{"TEAM_OWNERSHIP_INVALID"},
+		},
+		{
+			name: "global default receiver",
+			teams: []dsl.Team{
+				{Name: "payments", DefaultNotify: dsl.Targets{"global:catch-all"}},
+			},
+			globals:   []dsl.Channel{{Name: "catch-all"}},
+			wantCodes: nil,
+		},
+		{
+			name: "unknown default receiver",
+			teams: []dsl.Team{
+				{Name: "payments", DefaultNotify: dsl.Targets{"pager"}, Channels: []dsl.Channel{{Name: "slack"}}},
+			},
+			wantCodes: []string{"TEAM_DEFAULT_NOTIFY_UNKNOWN"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validators.NewTeamValidator(tt.teams, tt.globals).Validate()
+			var codes []string
+			for _, d := range diags {
+				codes = append(codes, d.Code)
+			}
+			assert.ElementsMatch(t, tt.wantCodes, codes)
+		})
+	}
+}
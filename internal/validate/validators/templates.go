@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"fmt"
+
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/tmpl"
+)
+
+// TemplatesValidator parses team notification templates with Alertmanager's
+// function map and checks that define names are unique across the project,
+// since Alertmanager loads all templates into one namespace.
+type TemplatesValidator struct {
+	teams []dsl.Team
+}
+
+func NewTemplatesValidator(teams []dsl.Team) Validator {
+	return TemplatesValidator{teams: teams}
+}
+
+func (v TemplatesValidator) Validate() []diag.Diagnostic {
+	var diags []diag.Diagnostic
+
+	type origin struct {
+		team, path string
+		line       int
+	}
+	defined := map[string]origin{}
+
+	for _, team := range v.teams {
+		for _, t := range team.Templates {
+			if _, err := tmpl.Parse(t.Name, t.Content); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Level:   diag.LevelError,
+					Code:    "TEMPLATE_PARSE",
+					Message: fmt.Sprintf("team %q template %s: %v", team.Name, t.Name, err),
+					File:    t.Path,
+					Line:    tmpl.ErrorLine(err),
+				})
+			}
+
+			for _, d := range tmpl.Defines(t.Content) {
+				if prev, ok := defined[d.Name]; ok {
+					diags = append(diags, diag.Diagnostic{
+						Level: diag.LevelError,
+						Code:  "TEMPLATE_DEFINE_DUP",
+						Message: fmt.Sprintf("team %q template %s defines %q, already defined by team %q at %s:%d",
+							team.Name, t.Name, d.Name, prev.team, prev.path, prev.line),
+						File: t.Path,
+						Line: d.Line,
+					})
+					continue
+				}
+				defined[d.Name] = origin{team: team.Name, path: t.Path, line: d.Line}
+			}
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,67 @@
+package validators_test
+
+import (
+	"testing"
+
+	"github.com/nyambati/fuse/internal/diag"
+	"github.com/nyambati/fuse/internal/dsl"
+	"github.com/nyambati/fuse/internal/validate/validators"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplatesValidator(t *testing.T) {
+	valid := `{{ define "payments.slack.title" }}[{{ .Status | toUpper }}] {{ .CommonLabels.alertname }}{{ end }}
+{{ define "payments.slack.text" }}{{ range .Alerts }}{{ .Annotations.summary }} ({{ .StartsAt | since | humanizeDuration }}){{ end }}{{ end }}`
+
+	tests := []struct {
+		name  string
+		teams []dsl.Team
+		want  []diag.Diagnostic
+	}{
+		{
+			name: "valid templates using alertmanager functions",
+			teams: []dsl.Team{
+				{Name: "payments", Templates: []dsl.Template{{Name: "slack.tmpl", Path: "teams/payments/templates/slack.tmpl", Content: valid}}},
+			},
+		},
+		{
+			name: "syntax error with line",
+			teams: []dsl.Team{
+				{Name: "payments", Templates: []dsl.Template{{
+					Name:    "slack.tmpl",
+					Path:    "teams/payments/templates/slack.tmpl",
+					Content: "{{ define \"a\" }}\nok\n{{ .Status | nosuchfunc }}\n{{ end }}",
+				}}},
+			},
+			want: []diag.Diagnostic{{
+				Level: diag.LevelError,
+				Code:  "TEMPLATE_PARSE",
+				File:  "teams/payments/templates/slack.tmpl",
+				Line:  3,
+			}},
+		},
+		{
+			name: "duplicate define across teams",
+			teams: []dsl.Team{
+				{Name: "billing", Templates: []dsl.Template{{Name: "slack.tmpl", Path: "teams/billing/templates/slack.tmpl", Content: `{{ define "slack.title" }}a{{ end }}`}}},
+				{Name: "payments", Templates: []dsl.Template{{Name: "slack.tmpl", Path: "teams/payments/templates/slack.tmpl", Content: "\n{{- define \"slack.title\" }}b{{ end }}"}}},
+			},
+			want: []diag.Diagnostic{{
+				Level: diag.LevelError,
+				Code:  "TEMPLATE_DEFINE_DUP",
+				File:  "teams/payments/templates/slack.tmpl",
+				Line:  2,
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validators.NewTemplatesValidator(tt.teams).Validate()
+			for i := range diags {
+				diags[i].Message = ""
+			}
+			assert.Equal(t, tt.want, diags)
+		})
+	}
+}